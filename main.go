@@ -19,6 +19,7 @@ import (
 	"github.com/gohornet/hornet/plugins/pow"
 	"github.com/gohornet/hornet/plugins/profiling"
 	"github.com/gohornet/hornet/plugins/prometheus"
+	"github.com/gohornet/hornet/plugins/reattacher"
 	"github.com/gohornet/hornet/plugins/snapshot"
 	"github.com/gohornet/hornet/plugins/spammer"
 	"github.com/gohornet/hornet/plugins/tangle"
@@ -59,6 +60,7 @@ func main() {
 			zmq.PLUGIN,
 			mqtt.PLUGIN,
 			spammer.PLUGIN,
+			reattacher.PLUGIN,
 			coordinator.PLUGIN,
 			prometheus.PLUGIN,
 		}...)
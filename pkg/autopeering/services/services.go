@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"hash/fnv"
 	"sync"
 
 	"github.com/gohornet/hornet/pkg/config"
@@ -19,3 +20,10 @@ func GossipServiceKey() service.Key {
 	})
 	return gossipServiceKey
 }
+
+// NetworkID returns the ID of the network, which is the hash of the gossip service key.
+func NetworkID() uint32 {
+	gossipServiceKeyHash := fnv.New32a()
+	gossipServiceKeyHash.Write([]byte(GossipServiceKey()))
+	return gossipServiceKeyHash.Sum32()
+}
@@ -14,6 +14,14 @@ const (
 	CfgNetGossipBindAddress = "network.gossip.bindAddress"
 	// the number of seconds to wait before trying to reconnect to a disconnected peer
 	CfgNetGossipReconnectAttemptIntervalSeconds = "network.gossip.reconnectAttemptIntervalSeconds"
+	// whether to disconnect peers which didn't send any transactions or requests within the idle timeout
+	CfgNetGossipAutoDisconnectIdleEnabled = "network.gossip.autoDisconnectIdle.enabled"
+	// the number of seconds without any transactions or requests after which a peer is disconnected
+	CfgNetGossipAutoDisconnectIdleTimeoutSeconds = "network.gossip.autoDisconnectIdle.timeoutSeconds"
+	// the DNS names which hold TXT records with the addresses of bootstrap peers
+	CfgNetDNSSeeds = "network.dnsSeeds.names"
+	// the interval in minutes in which the DNS seeds are resolved again
+	CfgNetDNSSeedsRefreshIntervalMinutes = "network.dnsSeeds.refreshIntervalMinutes"
 
 	// enable inbound connections from unknown peers
 	CfgPeeringAcceptAnyConnection = "acceptAnyConnection"
@@ -40,6 +48,19 @@ const (
 	CfgNetAutopeeringSaltLifetime = "network.autopeering.saltLifetime"
 	// maximum percentage of dropped packets in one minute before an autopeered neighbor gets dropped
 	CfgNetAutopeeringMaxDroppedPacketsPercentage = "network.autopeering.maxDroppedPacketsPercentage"
+
+	// the weight of the rate of new transactions received from a peer in its score
+	CfgNetPeerScoringWeightsData = "network.peerScoring.weights.data"
+	// the weight of the round-trip time to a peer in its score
+	CfgNetPeerScoringWeightsLatency = "network.peerScoring.weights.latency"
+	// the weight of the connection uptime of a peer in its score
+	CfgNetPeerScoringWeightsUptime = "network.peerScoring.weights.uptime"
+	// whether to disconnect the lowest scored autopeered neighbor if all autopeering slots are filled
+	CfgNetPeerScoringPruningEnabled = "network.peerScoring.pruning.enabled"
+	// the interval in seconds in which the lowest scored autopeered neighbor is disconnected
+	CfgNetPeerScoringPruningIntervalSeconds = "network.peerScoring.pruning.intervalSeconds"
+	// the number of seconds an autopeered neighbor has to be connected before it can be pruned
+	CfgNetPeerScoringPruningGracePeriodSeconds = "network.peerScoring.pruning.gracePeriodSeconds"
 )
 
 func init() {
@@ -48,6 +69,10 @@ func init() {
 	configFlagSet.Bool(CfgNetPreferIPv6, false, "defines if IPv6 is preferred for peers added through the API")
 	configFlagSet.String(CfgNetGossipBindAddress, "0.0.0.0:15600", "the bind address of the gossip TCP server")
 	configFlagSet.Int(CfgNetGossipReconnectAttemptIntervalSeconds, 60, "the number of seconds to wait before trying to reconnect to a disconnected peer")
+	configFlagSet.Bool(CfgNetGossipAutoDisconnectIdleEnabled, false, "whether to disconnect peers which didn't send any transactions or requests within the idle timeout")
+	configFlagSet.Int(CfgNetGossipAutoDisconnectIdleTimeoutSeconds, 300, "the number of seconds without any transactions or requests after which a peer is disconnected")
+	configFlagSet.StringSlice(CfgNetDNSSeeds, []string{}, "the DNS names which hold TXT records with the addresses of bootstrap peers")
+	configFlagSet.Int(CfgNetDNSSeedsRefreshIntervalMinutes, 60, "the interval in minutes in which the DNS seeds are resolved again")
 
 	// peering
 	peeringFlagSet.Bool(CfgPeeringAcceptAnyConnection, false, "enable inbound connections from unknown peers")
@@ -71,4 +96,12 @@ func init() {
 	configFlagSet.Int(CfgNetAutopeeringOutboundPeers, 2, "the number of outbound autopeers")
 	configFlagSet.Int(CfgNetAutopeeringSaltLifetime, 30, "lifetime (in minutes) of the private and public local salt")
 	configFlagSet.Int(CfgNetAutopeeringMaxDroppedPacketsPercentage, 0, "maximum percentage of dropped packets in one minute before an autopeered neighbor gets dropped (0 = disable)")
+
+	// peer scoring
+	configFlagSet.Float64(CfgNetPeerScoringWeightsData, 1.0, "the weight of the rate of new transactions received from a peer in its score")
+	configFlagSet.Float64(CfgNetPeerScoringWeightsLatency, 0.5, "the weight of the round-trip time to a peer in its score")
+	configFlagSet.Float64(CfgNetPeerScoringWeightsUptime, 0.5, "the weight of the connection uptime of a peer in its score")
+	configFlagSet.Bool(CfgNetPeerScoringPruningEnabled, false, "whether to disconnect the lowest scored autopeered neighbor if all autopeering slots are filled")
+	configFlagSet.Int(CfgNetPeerScoringPruningIntervalSeconds, 300, "the interval in seconds in which the lowest scored autopeered neighbor is disconnected")
+	configFlagSet.Int(CfgNetPeerScoringPruningGracePeriodSeconds, 600, "the number of seconds an autopeered neighbor has to be connected before it can be pruned")
 }
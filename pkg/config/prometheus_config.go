@@ -9,6 +9,8 @@ const (
 	CfgPrometheusProcessMetrics = "prometheus.processMetrics"
 	// include promhttp metrics
 	CfgPrometheusPromhttpMetrics = "prometheus.promhttpMetrics"
+	// include tip-selection metrics
+	CfgPrometheusTipSelectionMetrics = "prometheus.tipSelectionMetrics"
 	// whether the plugin should write a Prometheus 'file SD' file
 	CfgPrometheusFileServiceDiscoveryEnabled = "prometheus.fileServiceDiscovery.enabled"
 	// the path where to write the 'file SD' file to
@@ -22,6 +24,7 @@ func init() {
 	configFlagSet.Bool(CfgPrometheusGoMetrics, false, "include go metrics")
 	configFlagSet.Bool(CfgPrometheusProcessMetrics, false, "include process metrics")
 	configFlagSet.Bool(CfgPrometheusPromhttpMetrics, false, "include promhttp metrics")
+	configFlagSet.Bool(CfgPrometheusTipSelectionMetrics, true, "include tip-selection metrics")
 	configFlagSet.Bool(CfgPrometheusFileServiceDiscoveryEnabled, false, "whether the plugin should write a Prometheus 'file SD' file")
 	configFlagSet.String(CfgPrometheusFileServiceDiscoveryPath, "target.json", "the path where to write the 'file SD' file to")
 	configFlagSet.String(CfgPrometheusFileServiceDiscoveryTarget, "localhost:9311", "the target to write into the 'file SD' file")
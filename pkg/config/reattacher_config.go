@@ -0,0 +1,22 @@
+package config
+
+const (
+	// the maximum amount of bundles which are tracked for automatic promotion and reattachment
+	CfgReattacherMaxTrackedBundles = "reattacher.maxTrackedBundles"
+	// the interval in seconds in which the tracked bundles are checked
+	CfgReattacherCheckIntervalSeconds = "reattacher.checkIntervalSeconds"
+	// the time in minutes after which the tracking of an unconfirmed bundle is stopped
+	CfgReattacherDeadlineMinutes = "reattacher.deadlineMinutes"
+	// the tag of the promotion transactions
+	CfgReattacherPromotionTag = "reattacher.promotionTag"
+	// the maximum amount of times the tips are selected again if they became lazy during the PoW of an attachment (0 = disable)
+	CfgReattacherTipReselectionRetries = "reattacher.tipReselectionRetries"
+)
+
+func init() {
+	configFlagSet.Int(CfgReattacherMaxTrackedBundles, 100, "the maximum amount of bundles which are tracked for automatic promotion and reattachment")
+	configFlagSet.Int(CfgReattacherCheckIntervalSeconds, 30, "the interval in seconds in which the tracked bundles are checked")
+	configFlagSet.Int(CfgReattacherDeadlineMinutes, 60, "the time in minutes after which the tracking of an unconfirmed bundle is stopped")
+	configFlagSet.String(CfgReattacherPromotionTag, "HORNET99REATTACHER999999999", "the tag of the promotion transactions")
+	configFlagSet.Int(CfgReattacherTipReselectionRetries, 0, "the maximum amount of times the tips are selected again if they became lazy during the PoW of an attachment (0 = disable)")
+}
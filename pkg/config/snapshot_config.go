@@ -13,6 +13,8 @@ const (
 	CfgLocalSnapshotsPath = "snapshots.local.path"
 	// URL to load the local snapshot file from
 	CfgLocalSnapshotsDownloadURLs = "snapshots.local.downloadURLs"
+	// whether milestone confirmations are paused while the state of a local snapshot is collected
+	CfgLocalSnapshotsLockLedger = "snapshots.local.lockLedger"
 	// path to the global snapshot file containing the ledger state
 	CfgGlobalSnapshotPath = "snapshots.global.path"
 	// paths to the spent addresses files
@@ -34,6 +36,7 @@ func init() {
 	configFlagSet.Int(CfgLocalSnapshotsIntervalUnsynced, 1000, "interval, in milestone transactions, at which snapshot files are created if the ledger is not fully synchronized")
 	configFlagSet.String(CfgLocalSnapshotsPath, "snapshots/mainnet/export.bin", "path to the local snapshot file")
 	configFlagSet.StringSlice(CfgLocalSnapshotsDownloadURLs, []string{}, "URLs to load the local snapshot file from. Provide multiple URLs as fall back sources")
+	configFlagSet.Bool(CfgLocalSnapshotsLockLedger, true, "whether milestone confirmations are paused while the state of a local snapshot is collected")
 	configFlagSet.String(CfgGlobalSnapshotPath, "snapshotMainnet.txt", "path to the global snapshot file containing the ledger state")
 	configFlagSet.StringSlice(CfgGlobalSnapshotSpentAddressesPaths, []string{
 		"previousEpochsSpentAddresses1.txt",
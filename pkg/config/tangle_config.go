@@ -5,9 +5,24 @@ const (
 	CfgDatabasePath = "db.path"
 	// ignore the check for corrupted databases (should only be used for debug reasons)
 	CfgDatabaseDebug = "db.debug"
+	// the amount of recently confirmed transactions used to calculate the confirmation latency statistics
+	CfgTangleConfirmationLatencyWindow = "tangle.confirmationLatencyWindow"
+	// the amount of recently confirmed milestones for which the amount of conflicting bundles is kept
+	CfgTangleConflictsWindow = "tangle.conflictsWindow"
+	// the amount of recently received transactions for which the peer that delivered them first is kept (0 = disabled)
+	CfgTangleArrivalSourcesWindow = "tangle.arrivalSourcesWindow"
+	// the amount of recently received transactions used to calculate the transaction size statistics
+	CfgTangleTransactionSizesWindow = "tangle.transactionSizesWindow"
+	// the amount of recently confirmed transactions which can be queried via getRecentlyConfirmedTransactions
+	CfgTangleRecentConfirmationsWindow = "tangle.recentConfirmationsWindow"
 )
 
 func init() {
 	configFlagSet.String(CfgDatabasePath, "mainnetdb", "the path to the database folder")
 	configFlagSet.Bool(CfgDatabaseDebug, false, "ignore the check for corrupted databases (should only be used for debug reasons)")
+	configFlagSet.Int(CfgTangleConfirmationLatencyWindow, 10000, "the amount of recently confirmed transactions used to calculate the confirmation latency statistics")
+	configFlagSet.Int(CfgTangleConflictsWindow, 100, "the amount of recently confirmed milestones for which the amount of conflicting bundles is kept")
+	configFlagSet.Int(CfgTangleArrivalSourcesWindow, 0, "the amount of recently received transactions for which the peer that delivered them first is kept (0 = disabled)")
+	configFlagSet.Int(CfgTangleTransactionSizesWindow, 10000, "the amount of recently received transactions used to calculate the transaction size statistics")
+	configFlagSet.Int(CfgTangleRecentConfirmationsWindow, 1000, "the amount of recently confirmed transactions which can be queried via getRecentlyConfirmedTransactions")
 }
@@ -27,6 +27,14 @@ const (
 	// CfgTipSelSpammerTipsThreshold is the maximum amount of tips in a tip-pool before the spammer tries to reduce these (0 = disable (semi-lazy), 0 = always (non-lazy))
 	// this is used to support the network if someone attacks the tangle by spamming a lot of tips
 	CfgTipSelSpammerTipsThreshold = "spammerTipsThreshold"
+	// CfgTipSelFallbackToLatestMilestone defines whether the tail transaction of the latest solid milestone
+	// should be used as trunk and branch if no tips are available in the tip pool.
+	// this keeps the issuance of transactions working in low-traffic private networks.
+	CfgTipSelFallbackToLatestMilestone = "tipsel.fallbackToLatestMilestone"
+	// CfgTipSelAllowUnsynced defines whether tip selection is allowed against the local (possibly incomplete) tangle
+	// while the node is still syncing. this is unsafe, transactions attached during sync may be orphaned,
+	// because their past cone might be below max depth once the node caught up with the network.
+	CfgTipSelAllowUnsynced = "tipsel.allowUnsynced"
 )
 
 func init() {
@@ -50,4 +58,8 @@ func init() {
 		"before the tip is removed from the tip pool (semi-lazy)")
 	configFlagSet.Int(CfgTipSelSemiLazy+CfgTipSelSpammerTipsThreshold, 30, "the maximum amount of tips in a tip-pool (semi-lazy) before "+
 		"the spammer tries to reduce these (0 = disable)")
+	configFlagSet.Bool(CfgTipSelFallbackToLatestMilestone, false, "whether to use the latest solid milestone "+
+		"as trunk and branch if no tips are available")
+	configFlagSet.Bool(CfgTipSelAllowUnsynced, false, "whether to allow tip selection while the node is not synced "+
+		"(unsafe, transactions attached during sync may be orphaned)")
 }
@@ -27,6 +27,72 @@ const (
 	CfgWebAPILimitsMaxGetTrytes = "httpAPI.limits.getTrytes"
 	// the maximum number of parameters in an API call
 	CfgWebAPILimitsMaxRequestsList = "httpAPI.limits.requestsList"
+	// the maximum number of transactions that may be traversed by the getInclusionPath endpoint
+	CfgWebAPILimitsMaxInclusionPathTraversal = "httpAPI.limits.inclusionPathTraversal"
+	// the maximum number of transactions that may be requested at once by the transactions by-hashes route
+	CfgWebAPILimitsMaxTransactionsByHashes = "httpAPI.limits.transactionsByHashes"
+	// whether to answer legacy IRI API calls which are not supported by HORNET with a structured "not supported" error
+	CfgWebAPILegacyCompatibility = "httpAPI.legacyCompatibility"
+	// the time in seconds the results of attachToTangle and broadcastTransactions are cached for an idempotency key (0 = disabled)
+	CfgWebAPIIdempotencyKeyTTLSeconds = "httpAPI.idempotencyKeyTTLSeconds"
+	// the maximum number of idempotency keys which are cached, the oldest keys are removed first
+	CfgWebAPIIdempotencyMaxKeys = "httpAPI.idempotencyMaxKeys"
+	// whether the incoming transaction filter may be cleared via the API (should only be enabled in test environments)
+	CfgWebAPIDebugAllowClearTransactionFilter = "httpAPI.debug.allowClearTransactionFilter"
+	// whether stored transactions may be run through the checks of the transaction processor again via the API (should only be enabled in test environments)
+	CfgWebAPIDebugAllowReprocessTransaction = "httpAPI.debug.allowReprocessTransaction"
+	// whether milestones of an external coordinator may be submitted via the API (private networks only)
+	CfgWebAPIAllowSubmitMilestone = "httpAPI.allowSubmitMilestone"
+	// the maximum allowed difference in seconds between the attachment timestamp of broadcasted transactions and the node's time (0 = disabled)
+	CfgWebAPIMaxAttachmentTimestampSkewSeconds = "httpAPI.maxAttachmentTimestampSkewSeconds"
+	// the maximum amount of submissions which are processed in parallel per connection of the broadcast stream
+	CfgWebAPIStreamBroadcastMaxInFlight = "httpAPI.streamBroadcast.maxInFlight"
+	// the maximum amount of submissions of the broadcast stream which are processed in parallel across all connections
+	CfgWebAPIStreamBroadcastWorkers = "httpAPI.streamBroadcast.workers"
+	// the maximum amount of concurrent subscribers of all stream routes (0 = unlimited)
+	CfgWebAPIStreamsMaxSubscribers = "httpAPI.streams.maxSubscribers"
+	// the maximum amount of concurrent subscribers per stream route (0 = unlimited)
+	CfgWebAPIStreamsMaxSubscribersPerRoute = "httpAPI.streams.maxSubscribersPerRoute"
+	// the maximum amount of messages which are buffered per subscriber of a stream route before the connection is closed
+	CfgWebAPIStreamsSendBufferSize = "httpAPI.streams.sendBufferSize"
+	// the default time in seconds the PoW of the whole bundle of attachToTangle may take before the request is aborted (0 = unlimited)
+	CfgWebAPIPoWTimeoutSeconds = "httpAPI.powTimeoutSeconds"
+	// the maximum time in seconds a client may request as PoW timeout of attachToTangle (0 = unlimited)
+	CfgWebAPIMaxPoWTimeoutSeconds = "httpAPI.maxPoWTimeoutSeconds"
+	// the maximum number of transactions for which the PoW of attachToTangle is done at the same time
+	CfgWebAPIMaxConcurrentPoW = "httpAPI.maxConcurrentPoW"
+	// the maximum amount of addresses a subscriber of the address stream can subscribe to (0 = unlimited)
+	CfgWebAPIStreamsMaxAddressesPerSubscriber = "httpAPI.streams.maxAddressesPerSubscriber"
+	// the route templates (e.g. "/milestones/:index") and API commands which are mounted, a trailing "*" matches any suffix (empty = all)
+	CfgWebAPIEnabledRoutes = "httpAPI.enabledRoutes"
+	// the route templates (e.g. "/milestones/:index") and API commands which are not mounted, a trailing "*" matches any suffix
+	CfgWebAPIDisabledRoutes = "httpAPI.disabledRoutes"
+	// the maximum amount of stored transactions per tag prefix, in the format "PREFIX:COUNT"
+	CfgWebAPITagQuotas = "httpAPI.tagQuotas"
+	// whether transactions submitted via broadcastTransactions may be stored without broadcasting them to the neighbors
+	CfgWebAPIAllowSkipBroadcast = "httpAPI.allowSkipBroadcast"
+	// the minimum amount of connected peers needed to accept transactions for broadcasting (0 = disabled)
+	CfgWebAPIMinConnectedPeers = "httpAPI.minConnectedPeers"
+	// the maximum amount of message trytes in a zero-value bundle submitted via the API (0 = disabled)
+	CfgWebAPIMaxDataTrytesPerBundle = "httpAPI.maxDataTrytesPerBundle"
+	// the checks which have to pass for the health check route to report the node as healthy
+	CfgWebAPIHealthzChecks = "httpAPI.healthz.checks"
+	// the minimum amount of connected peers for the "peers" health check
+	CfgWebAPIHealthzMinConnectedPeers = "httpAPI.healthz.minConnectedPeers"
+	// the maximum amount of milestones which are not solid yet for the "solidificationBacklog" health check
+	CfgWebAPIHealthzMaxMilestonesToSolidify = "httpAPI.healthz.maxMilestonesToSolidify"
+	// the maximum duration in seconds of a snapshot file creation via the API (0 = disabled)
+	CfgWebAPICreateSnapshotTimeoutSeconds = "httpAPI.createSnapshotTimeoutSeconds"
+	// whether to log the requests of the selected routes and API commands
+	CfgWebAPIRequestLogEnabled = "httpAPI.requestLog.enabled"
+	// the route templates (e.g. "/milestones/:index") and API commands which are logged ("*" = all)
+	CfgWebAPIRequestLogRoutes = "httpAPI.requestLog.routes"
+	// the level of the request log entries [debug, info, warn]
+	CfgWebAPIRequestLogLevel = "httpAPI.requestLog.level"
+	// the file the requests are written to as JSON lines (empty = node logger)
+	CfgWebAPIRequestLogOutputPath = "httpAPI.requestLog.outputPath"
+	// the request headers whose values are redacted in addition to the authorization headers and cookies
+	CfgWebAPIRequestLogRedactedHeaders = "httpAPI.requestLog.redactedHeaders"
 )
 
 func init() {
@@ -38,6 +104,7 @@ func init() {
 			"getTransactionsToApprove",
 			"getInclusionStates",
 			"getNodeAPIConfiguration",
+			"getProtocolParameters",
 			"wereAddressesSpentFrom",
 			"broadcastTransactions",
 			"findTransactions",
@@ -58,4 +125,37 @@ func init() {
 	configFlagSet.Int(CfgWebAPILimitsMaxFindTransactions, 1000, "the maximum number of transactions that may be returned by the findTransactions endpoint")
 	configFlagSet.Int(CfgWebAPILimitsMaxGetTrytes, 1000, "the maximum number of trytes that may be returned by the getTrytes endpoint")
 	configFlagSet.Int(CfgWebAPILimitsMaxRequestsList, 1000, "the maximum number of parameters in an API call")
+	configFlagSet.Int(CfgWebAPILimitsMaxInclusionPathTraversal, 100000, "the maximum number of transactions that may be traversed by the getInclusionPath endpoint")
+	configFlagSet.Int(CfgWebAPILimitsMaxTransactionsByHashes, 100, "the maximum number of transactions that may be requested at once by the transactions by-hashes route")
+	configFlagSet.Bool(CfgWebAPILegacyCompatibility, true, "whether to answer legacy IRI API calls which are not supported by HORNET with a structured \"not supported\" error")
+	configFlagSet.Int(CfgWebAPIIdempotencyKeyTTLSeconds, 600, "the time in seconds the results of attachToTangle and broadcastTransactions are cached for an idempotency key (0 = disabled)")
+	configFlagSet.Int(CfgWebAPIIdempotencyMaxKeys, 10000, "the maximum number of idempotency keys which are cached, the oldest keys are removed first")
+	configFlagSet.Bool(CfgWebAPIDebugAllowClearTransactionFilter, false, "whether the incoming transaction filter may be cleared via the API (should only be enabled in test environments)")
+	configFlagSet.Bool(CfgWebAPIDebugAllowReprocessTransaction, false, "whether stored transactions may be run through the checks of the transaction processor again via the API (should only be enabled in test environments)")
+	configFlagSet.Bool(CfgWebAPIAllowSubmitMilestone, false, "whether milestones of an external coordinator may be submitted via the API (private networks only)")
+	configFlagSet.Int(CfgWebAPIMaxAttachmentTimestampSkewSeconds, 0, "the maximum allowed difference in seconds between the attachment timestamp of broadcasted transactions and the node's time (0 = disabled)")
+	configFlagSet.Int(CfgWebAPIStreamBroadcastMaxInFlight, 16, "the maximum amount of submissions which are processed in parallel per connection of the broadcast stream")
+	configFlagSet.Int(CfgWebAPIStreamBroadcastWorkers, 4, "the maximum amount of submissions of the broadcast stream which are processed in parallel across all connections")
+	configFlagSet.Int(CfgWebAPIStreamsMaxSubscribers, 1000, "the maximum amount of concurrent subscribers of all stream routes (0 = unlimited)")
+	configFlagSet.Int(CfgWebAPIStreamsMaxSubscribersPerRoute, 250, "the maximum amount of concurrent subscribers per stream route (0 = unlimited)")
+	configFlagSet.Int(CfgWebAPIStreamsSendBufferSize, 100, "the maximum amount of messages which are buffered per subscriber of a stream route before the connection is closed")
+	configFlagSet.Int(CfgWebAPIPoWTimeoutSeconds, 60, "the default time in seconds the PoW of the whole bundle of attachToTangle may take before the request is aborted (0 = unlimited)")
+	configFlagSet.Int(CfgWebAPIMaxPoWTimeoutSeconds, 300, "the maximum time in seconds a client may request as PoW timeout of attachToTangle (0 = unlimited)")
+	configFlagSet.Int(CfgWebAPIMaxConcurrentPoW, 1, "the maximum number of transactions for which the PoW of attachToTangle is done at the same time")
+	configFlagSet.Int(CfgWebAPIStreamsMaxAddressesPerSubscriber, 100, "the maximum amount of addresses a subscriber of the address stream can subscribe to (0 = unlimited)")
+	configFlagSet.StringSlice(CfgWebAPIEnabledRoutes, []string{}, "the route templates (e.g. \"/milestones/:index\") and API commands which are mounted, a trailing \"*\" matches any suffix (empty = all)")
+	configFlagSet.StringSlice(CfgWebAPIDisabledRoutes, []string{}, "the route templates (e.g. \"/milestones/:index\") and API commands which are not mounted, a trailing \"*\" matches any suffix")
+	configFlagSet.StringSlice(CfgWebAPITagQuotas, []string{}, "the maximum amount of stored transactions per tag prefix, in the format \"PREFIX:COUNT\"")
+	configFlagSet.Bool(CfgWebAPIAllowSkipBroadcast, false, "whether transactions submitted via broadcastTransactions may be stored without broadcasting them to the neighbors")
+	configFlagSet.Int(CfgWebAPIMinConnectedPeers, 0, "the minimum amount of connected peers needed to accept transactions for broadcasting (0 = disabled)")
+	configFlagSet.Int(CfgWebAPIMaxDataTrytesPerBundle, 0, "the maximum amount of message trytes in a zero-value bundle submitted via the API (0 = disabled)")
+	configFlagSet.StringSlice(CfgWebAPIHealthzChecks, []string{"synced", "peers", "milestoneAge"}, "the checks which have to pass for the health check route to report the node as healthy")
+	configFlagSet.Int(CfgWebAPIHealthzMinConnectedPeers, 1, "the minimum amount of connected peers for the \"peers\" health check")
+	configFlagSet.Int(CfgWebAPIHealthzMaxMilestonesToSolidify, 2, "the maximum amount of milestones which are not solid yet for the \"solidificationBacklog\" health check")
+	configFlagSet.Int(CfgWebAPICreateSnapshotTimeoutSeconds, 0, "the maximum duration in seconds of a snapshot file creation via the API (0 = disabled)")
+	configFlagSet.Bool(CfgWebAPIRequestLogEnabled, false, "whether to log the requests of the selected routes and API commands")
+	configFlagSet.StringSlice(CfgWebAPIRequestLogRoutes, []string{"/spammer", "createSnapshotFile", "pruneDatabase", "addNeighbors", "removeNeighbors"}, "the route templates (e.g. \"/milestones/:index\") and API commands which are logged (\"*\" = all)")
+	configFlagSet.String(CfgWebAPIRequestLogLevel, "info", "the level of the request log entries [debug, info, warn]")
+	configFlagSet.String(CfgWebAPIRequestLogOutputPath, "", "the file the requests are written to as JSON lines (empty = node logger)")
+	configFlagSet.StringSlice(CfgWebAPIRequestLogRedactedHeaders, []string{}, "the request headers whose values are redacted in addition to the authorization headers and cookies")
 }
@@ -56,4 +56,12 @@ type ServerMetrics struct {
 	TipsNonLazy atomic.Uint32
 	// The number of semi-lazy tips.
 	TipsSemiLazy atomic.Uint32
+	// The number of tip selections performed by the tip-selector.
+	TipSelections atomic.Uint32
+	// The number of transactions which were rejected by the API because of a tag quota.
+	RejectedTagQuotaTransactions atomic.Uint32
+	// The number of submissions of the broadcast stream which wait for a free worker.
+	StreamBroadcastQueueDepth atomic.Uint32
+	// The number of connected subscribers of all stream routes of the API.
+	StreamSubscribers atomic.Uint32
 }
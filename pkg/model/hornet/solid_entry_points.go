@@ -31,6 +31,10 @@ func (s *SolidEntryPoints) Hashes() Hashes {
 	return solidEntryPointCopy
 }
 
+func (s *SolidEntryPoints) Len() int {
+	return len(s.entryPointsSlice)
+}
+
 func (s *SolidEntryPoints) Contains(txHash Hash) bool {
 	_, exists := s.entryPointsMap[string(txHash)]
 	return exists
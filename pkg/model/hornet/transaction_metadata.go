@@ -36,6 +36,9 @@ type TransactionMetadata struct {
 	// The index of the milestone which confirmed this tx
 	confirmationIndex milestone.Index
 
+	// whiteFlagPosition is the position of the bundle in the white flag order of the confirming milestone, plus one (0 = unknown)
+	whiteFlagPosition uint32
+
 	// youngestRootSnapshotIndex is the highest confirmed index of the past cone of this transaction
 	youngestRootSnapshotIndex milestone.Index
 
@@ -150,12 +153,36 @@ func (m *TransactionMetadata) SetConfirmed(confirmed bool, confirmationIndex mil
 			m.confirmationIndex = confirmationIndex
 		} else {
 			m.confirmationIndex = 0
+			m.whiteFlagPosition = 0
 		}
 		m.metadata = m.metadata.ModifyBit(TransactionMetadataConfirmed, confirmed)
 		m.SetModified(true)
 	}
 }
 
+// GetWhiteFlagIndex returns the position of the bundle of this tx in the white flag order of the confirming milestone.
+// The second return value is false if the tx is not confirmed or was confirmed before the index was persisted.
+func (m *TransactionMetadata) GetWhiteFlagIndex() (uint32, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	if !m.metadata.HasBit(TransactionMetadataConfirmed) || m.whiteFlagPosition == 0 {
+		return 0, false
+	}
+
+	return m.whiteFlagPosition - 1, true
+}
+
+func (m *TransactionMetadata) SetWhiteFlagIndex(whiteFlagIndex uint32) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.whiteFlagPosition != whiteFlagIndex+1 {
+		m.whiteFlagPosition = whiteFlagIndex + 1
+		m.SetModified(true)
+	}
+}
+
 func (m *TransactionMetadata) IsConflicting() bool {
 	m.RLock()
 	defer m.RUnlock()
@@ -232,6 +259,7 @@ func (m *TransactionMetadata) ObjectStorageValue() (data []byte) {
 		49 bytes hash trunk
 		49 bytes hash branch
 		49 bytes hash bundle
+		4 bytes uint32 whiteFlagPosition (only if the hashes are set)
 	*/
 
 	value := make([]byte, 21)
@@ -245,6 +273,12 @@ func (m *TransactionMetadata) ObjectStorageValue() (data []byte) {
 	value = append(value, m.branchHash...)
 	value = append(value, m.bundleHash...)
 
+	if len(value) == 21+49+49+49 {
+		whiteFlagPosition := make([]byte, 4)
+		binary.LittleEndian.PutUint32(whiteFlagPosition, m.whiteFlagPosition)
+		value = append(value, whiteFlagPosition...)
+	}
+
 	return value
 }
 
@@ -262,6 +296,7 @@ func (m *TransactionMetadata) UnmarshalObjectStorageValue(data []byte) (err erro
 		49 bytes hash trunk
 		49 bytes hash branch
 		49 bytes hash bundle
+		4 bytes uint32 whiteFlagPosition (only if the hashes are set)
 	*/
 
 	m.metadata = bitmask.BitMask(data[0])
@@ -275,11 +310,15 @@ func (m *TransactionMetadata) UnmarshalObjectStorageValue(data []byte) (err erro
 		// ToDo: Remove at next DbVersion update
 		m.rootSnapshotCalculationIndex = milestone.Index(binary.LittleEndian.Uint32(data[17:21]))
 
-		if len(data) == 21+49+49+49 {
+		if len(data) >= 21+49+49+49 {
 			m.trunkHash = Hash(data[21 : 21+49])
 			m.branchHash = Hash(data[21+49 : 21+49+49])
 			m.bundleHash = Hash(data[21+49+49 : 21+49+49+49])
 		}
+
+		if len(data) == 21+49+49+49+4 {
+			m.whiteFlagPosition = binary.LittleEndian.Uint32(data[21+49+49+49 : 21+49+49+49+4])
+		}
 	}
 
 	return nil
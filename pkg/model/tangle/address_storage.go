@@ -94,6 +94,88 @@ func GetTransactionHashesForAddress(address hornet.Hash, valueOnly bool, forceRe
 	return txHashes
 }
 
+// addressSeekEnumerationDepth is the amount of leading bytes of a transaction hash for which the bigger byte values
+// are enumerated when seeking in the address index. the keys sharing a longer prefix are filtered while iterating.
+const addressSeekEnumerationDepth = 2
+
+// ForEachTransactionHashForAddress iterates over the transaction hashes of the given address in the order of the database keys,
+// starting after the given transaction hash (nil = from the beginning). the non-value transactions come before the value transactions.
+// since the addresses are stored on creation, the persistence layer contains all addresses and is iterated directly.
+func ForEachTransactionHashForAddress(address hornet.Hash, valueOnly bool, startAfterTxHash hornet.Hash, consumer func(txHash hornet.Hash, isValue bool) bool) {
+
+	groups := []byte{0, hornet.AddressTxIsValue}
+	if valueOnly {
+		groups = groups[1:]
+	}
+
+	if startAfterTxHash != nil {
+		if ContainsAddress(address, startAfterTxHash, true) {
+			groups = []byte{hornet.AddressTxIsValue}
+		} else if valueOnly {
+			// the transaction is not part of the value transactions, so there is no position to start after
+			startAfterTxHash = nil
+		}
+	}
+
+	for _, group := range groups {
+		groupPrefix := append(append([]byte{}, databaseKeyPrefixForAddress(address)...), group)
+
+		if !forEachAddressKeyAfter(groupPrefix, startAfterTxHash, func(key []byte) bool {
+			return consumer(key[50:99], key[49] == hornet.AddressTxIsValue)
+		}) {
+			return
+		}
+
+		// the following groups are iterated from the beginning
+		startAfterTxHash = nil
+	}
+}
+
+// forEachAddressKeyAfter iterates over the keys with the given prefix which are bigger than the prefix followed by the given transaction hash.
+// the key-value store only supports prefix iterations, so the seek is emulated: the keys after the transaction hash are the ones
+// sharing its first i bytes and having a bigger byte at position i, for i from the last byte down to the first one.
+// returns false if the consumer aborted the iteration.
+func forEachAddressKeyAfter(prefix []byte, afterTxHash hornet.Hash, consumer func(key []byte) bool) bool {
+	aborted := false
+	iterate := func(iterationPrefix []byte, filter func(key []byte) bool) {
+		addressesStorage.ForEachKeyOnly(func(key []byte) bool {
+			if filter != nil && !filter(key) {
+				return true
+			}
+			if !consumer(key) {
+				aborted = true
+				return false
+			}
+			return true
+		}, true, iterationPrefix)
+	}
+
+	if afterTxHash == nil {
+		iterate(prefix, nil)
+		return !aborted
+	}
+
+	for i := len(afterTxHash) - 1; i >= 0 && !aborted; i-- {
+		levelPrefix := append(append([]byte{}, prefix...), afterTxHash[:i]...)
+
+		if i >= addressSeekEnumerationDepth {
+			// only a few keys share such a long prefix, so they are filtered while iterating
+			hashByteIndex := len(prefix) + i
+			iterate(levelPrefix, func(key []byte) bool {
+				return key[hashByteIndex] > afterTxHash[i]
+			})
+			continue
+		}
+
+		// the keys sharing a short prefix are too many to filter, so the bigger byte values are enumerated
+		for b := int(afterTxHash[i]) + 1; b <= 0xFF && !aborted; b++ {
+			iterate(append(append([]byte{}, levelPrefix...), byte(b)), nil)
+		}
+	}
+
+	return !aborted
+}
+
 // ContainsAddress returns if the given address exists in the cache/persistence layer.
 func ContainsAddress(address hornet.Hash, txHash hornet.Hash, valueOnly bool) bool {
 	if valueOnly {
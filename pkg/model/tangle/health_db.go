@@ -1,6 +1,8 @@
 package tangle
 
 import (
+	"encoding/binary"
+
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/hive.go/kvstore"
@@ -58,6 +60,53 @@ func IsDatabaseTainted() bool {
 	return contains
 }
 
+// CheckDatabaseWritable checks whether the database accepts writes by writing and deleting a probe entry.
+func CheckDatabaseWritable() error {
+
+	if err := healthStore.Set([]byte("writeProbe"), []byte{}); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to write database probe")
+	}
+
+	if err := healthStore.Delete([]byte("writeProbe")); err != nil {
+		return errors.Wrap(NewDatabaseError(err), "failed to delete database probe")
+	}
+
+	return nil
+}
+
+// persistStoredTransactionsCount stores the amount of transactions at shutdown, so it doesn't need to be counted at the next startup.
+func persistStoredTransactionsCount(count int64) {
+
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, uint64(count))
+	if err := healthStore.Set([]byte("txCount"), value); err != nil {
+		panic(errors.Wrap(NewDatabaseError(err), "failed to set stored transactions count"))
+	}
+}
+
+// loadStoredTransactionsCount returns the amount of transactions persisted at the last shutdown and removes it,
+// since the count is outdated as soon as the storage is modified. if the node crashed, the count doesn't exist.
+func loadStoredTransactionsCount() (count int64, exists bool) {
+
+	value, err := healthStore.Get([]byte("txCount"))
+	if err != nil {
+		if err == kvstore.ErrKeyNotFound {
+			return 0, false
+		}
+		panic(errors.Wrap(NewDatabaseError(err), "failed to read stored transactions count"))
+	}
+
+	if err := healthStore.Delete([]byte("txCount")); err != nil {
+		panic(errors.Wrap(NewDatabaseError(err), "failed to delete stored transactions count"))
+	}
+
+	if len(value) != 8 {
+		return 0, false
+	}
+
+	return int64(binary.LittleEndian.Uint64(value)), true
+}
+
 func setDatabaseVersion() {
 	_, err := healthStore.Get([]byte("dbVersion"))
 	if err == kvstore.ErrKeyNotFound {
@@ -68,6 +117,24 @@ func setDatabaseVersion() {
 	}
 }
 
+// GetDatabaseVersion returns the schema version of the database on disk (0 if unknown).
+func GetDatabaseVersion() int {
+
+	value, err := healthStore.Get([]byte("dbVersion"))
+	if err != nil {
+		if err == kvstore.ErrKeyNotFound {
+			return 0
+		}
+		panic(errors.Wrap(NewDatabaseError(err), "failed to read database version"))
+	}
+
+	if len(value) > 0 {
+		return int(value[0])
+	}
+
+	return 0
+}
+
 func IsCorrectDatabaseVersion() bool {
 
 	value, err := healthStore.Get([]byte("dbVersion"))
@@ -100,7 +167,11 @@ func UpdateDatabaseVersion() bool {
 		if err := migrateVersionOneToVersionTwo(); err != nil {
 			panic(errors.Wrap(NewDatabaseError(err), "failed to migrate database to new version"))
 		}
-		setDatabaseVersion()
+
+		if err := healthStore.Set([]byte("dbVersion"), []byte{DbVersion}); err != nil {
+			panic(errors.Wrap(NewDatabaseError(err), "failed to set database version"))
+		}
+
 		return true
 	}
 
@@ -3,9 +3,11 @@ package tangle
 import (
 	"encoding/binary"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/iota.go/consts"
@@ -175,6 +177,55 @@ func GetLedgerDiffForMilestoneWithoutLocking(index milestone.Index, abortSignal
 	return diff, nil
 }
 
+// GetLedgerDiffForAddressWithoutLocking returns the balance change of the given address in the ledger diff
+// of that specific milestone, and whether the address was changed at all.
+// ReadLockLedger must be held while entering this function.
+func GetLedgerDiffForAddressWithoutLocking(index milestone.Index, address hornet.Hash) (int64, bool, error) {
+
+	value, err := ledgerDiffStore.Get(databaseKeyForLedgerDiffAndAddress(index, address))
+	if err != nil {
+		if err != kvstore.ErrKeyNotFound {
+			return 0, false, errors.Wrap(NewDatabaseError(err), "failed to retrieve ledger diff")
+		}
+		return 0, false, nil
+	}
+
+	return diffFromBytes(value), true, nil
+}
+
+// GetLedgerDiffSizeForMilestoneWithoutLocking returns the amount of deposits and withdrawals in the ledger diff
+// of that specific milestone, without collecting the actual changes.
+// ReadLockLedger must be held while entering this function.
+func GetLedgerDiffSizeForMilestoneWithoutLocking(index milestone.Index, abortSignal <-chan struct{}) (deposits int, withdrawals int, err error) {
+
+	aborted := false
+	err = ledgerDiffStore.Iterate(databaseKeyForMilestoneIndex(index), func(key kvstore.Key, value kvstore.Value) bool {
+		select {
+		case <-abortSignal:
+			aborted = true
+			return false
+		default:
+		}
+
+		if diffFromBytes(value) < 0 {
+			withdrawals++
+		} else {
+			deposits++
+		}
+		return true
+	})
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if aborted {
+		return 0, 0, ErrOperationAborted
+	}
+
+	return deposits, withdrawals, nil
+}
+
 // LedgerDiffHashConsumer consumes the given ledger diff addresses during looping through all ledger diffs in the persistence layer.
 type LedgerDiffHashConsumer func(msIndex milestone.Index, address hornet.Hash) bool
 
@@ -391,3 +442,33 @@ func GetLedgerStateForLSMI(abortSignal <-chan struct{}) (map[string]uint64, mile
 
 	return GetLedgerStateForLSMIWithoutLocking(abortSignal)
 }
+
+// ComputeLedgerStateHash computes a BLAKE2b-256 hash over the given ledger state.
+// the addresses are sorted lexicographically by their 49 byte binary representation
+// and each address is followed by its balance as 8 byte little endian unsigned integer.
+func ComputeLedgerStateHash(balances map[string]uint64) ([]byte, error) {
+
+	addresses := make([]string, 0, len(balances))
+	for address := range balances {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	balanceBytes := make([]byte, 8)
+	for _, address := range addresses {
+		if _, err := hasher.Write([]byte(address)); err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint64(balanceBytes, balances[address])
+		if _, err := hasher.Write(balanceBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	return hasher.Sum(nil), nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/merkle"
+	"github.com/iotaledger/iota.go/transaction"
 	"github.com/iotaledger/iota.go/trinary"
 
 	"github.com/iotaledger/hive.go/syncutils"
@@ -357,6 +358,66 @@ func CheckIfMilestone(bndl *Bundle) (result bool, err error) {
 	return true, nil
 }
 
+// ValidateMilestoneTransactions checks whether the given transactions of a bundle, sorted by their index, form
+// a milestone which was signed by the configured coordinator and returns the index of the milestone.
+// In contrast to CheckIfMilestone, the transactions don't need to be stored, but the hashes of the transactions have to be set.
+func ValidateMilestoneTransactions(txs []*transaction.Transaction) (milestone.Index, error) {
+
+	if len(txs) != (coordinatorSecurityLevel + 1) {
+		return 0, errors.Wrapf(ErrInvalidMilestone, "wrong amount of transactions in bundle: %d, expected: %d", len(txs), coordinatorSecurityLevel+1)
+	}
+
+	for i, tx := range txs {
+		if tx.CurrentIndex != uint64(i) || tx.LastIndex != uint64(coordinatorSecurityLevel) {
+			return 0, errors.Wrapf(ErrInvalidMilestone, "invalid transaction index. Got: %d, expected: %d", tx.CurrentIndex, i)
+		}
+
+		if tx.Bundle != txs[0].Bundle {
+			return 0, errors.Wrapf(ErrInvalidMilestone, "bundle hash mismatch, Hash: %v", tx.Hash)
+		}
+
+		if i < coordinatorSecurityLevel && tx.TrunkTransaction != txs[i+1].Hash {
+			return 0, errors.Wrapf(ErrInvalidMilestone, "Structure is wrong, Hash: %v", tx.Hash)
+		}
+
+		isCooAddress := bytes.Equal(hornet.HashFromAddressTrytes(tx.Address), coordinatorAddress)
+		isNullAddress := bytes.Equal(hornet.HashFromAddressTrytes(tx.Address), hornet.NullHashBytes)
+		if tx.Value != 0 || !(isCooAddress || (i == coordinatorSecurityLevel && isNullAddress)) {
+			return 0, errors.Wrapf(ErrInvalidMilestone, "Transaction was not issued by compass, Hash: %v", tx.Hash)
+		}
+	}
+
+	milestoneIndex := milestone.Index(trinary.TrytesToInt(txs[0].ObsoleteTag))
+	if milestoneIndex == 0 || milestoneIndex >= maxMilestoneIndex {
+		return 0, errors.Wrapf(ErrInvalidMilestone, "invalid milestone index: %d", milestoneIndex)
+	}
+
+	siblingsTx := txs[coordinatorSecurityLevel]
+
+	var fragments []trinary.Trytes
+	for _, signatureTx := range txs[:coordinatorSecurityLevel] {
+		if signatureTx.BranchTransaction != siblingsTx.TrunkTransaction {
+			return 0, errors.Wrapf(ErrInvalidMilestone, "Structure is wrong, Hash: %v", txs[0].Hash)
+		}
+		fragments = append(fragments, signatureTx.SignatureMessageFragment)
+	}
+
+	var path []trinary.Trytes
+	for i := 0; i < int(coordinatorMerkleTreeDepth); i++ {
+		path = append(path, siblingsTx.SignatureMessageFragment[i*consts.HashTrytesSize:(i+1)*consts.HashTrytesSize])
+	}
+
+	// verify milestone signature
+	if valid, err := merkle.ValidateSignatureFragments(coordinatorAddress.Trytes(), uint32(milestoneIndex), path, fragments, siblingsTx.Hash); !valid {
+		if err != nil {
+			return 0, errors.Wrap(ErrInvalidMilestone, err.Error())
+		}
+		return 0, errors.Wrapf(ErrInvalidMilestone, "Signature was not valid, Hash: %v", txs[0].Hash)
+	}
+
+	return milestoneIndex, nil
+}
+
 // Checks if the the tx could be part of a milestone.
 func IsMaybeMilestone(cachedTx *CachedTransaction) bool {
 	value := (cachedTx.GetTransaction().Tx.Value == 0) && (bytes.Equal(cachedTx.GetTransaction().GetAddress(), coordinatorAddress))
@@ -59,6 +59,17 @@ func SolidEntryPointsContain(txHash hornet.Hash) bool {
 	return solidEntryPoints.Contains(txHash)
 }
 
+// SolidEntryPointsCount returns the amount of solid entry points without copying them.
+func SolidEntryPointsCount() int {
+	ReadLockSolidEntryPoints()
+	defer ReadUnlockSolidEntryPoints()
+
+	if solidEntryPoints == nil {
+		panic(ErrSolidEntryPointsNotInitialized)
+	}
+	return solidEntryPoints.Len()
+}
+
 func SolidEntryPointsIndex(txHash hornet.Hash) (milestone.Index, bool) {
 	ReadLockSolidEntryPoints()
 	defer ReadUnlockSolidEntryPoints()
@@ -1,16 +1,28 @@
 package tangle
 
 import (
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/iota.go/trinary"
+	"go.uber.org/atomic"
 
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/profile"
 )
 
-var tagsStorage *objectstorage.ObjectStorage
+var (
+	tagsStorage *objectstorage.ObjectStorage
+
+	// tagPrefixCounters count the stored transactions with a tag that starts with the registered prefixes.
+	tagPrefixCounters = make(map[trinary.Trytes]*atomic.Int64)
+	// tagPrefixCountersLock is held exclusively while a counter is registered,
+	// so transactions stored or deleted during the initial count are not counted twice.
+	tagPrefixCountersLock sync.RWMutex
+)
 
 type CachedTag struct {
 	objectstorage.CachedObject
@@ -74,6 +86,68 @@ func GetTagHashes(txTag hornet.Hash, forceRelease bool, maxFind ...int) hornet.H
 	return tagHashes
 }
 
+// CountTagPrefixHashes counts the transactions with a tag that starts with the given tag prefix, up to maxCount (0 = unlimited).
+// prefixes shorter than 2 trytes scan the whole tag storage, use a registered tag prefix counter for repeated counts.
+// only the complete bytes of the binary encoded prefix (5 trits per byte) can be used to search the storage,
+// so the remaining trytes of the prefix are compared after decoding the tags.
+func CountTagPrefixHashes(tagPrefix trinary.Trytes, maxCount int) int {
+	keyPrefix := hornet.HashFromTagTrytes(trinary.MustPad(tagPrefix, 27))[:len(tagPrefix)*3/5]
+
+	count := 0
+	tagsStorage.ForEachKeyOnly(func(key []byte) bool {
+		if !strings.HasPrefix(hornet.Hash(key[:17]).Trytes(), tagPrefix) {
+			return true
+		}
+
+		count++
+		return maxCount == 0 || count < maxCount
+	}, false, keyPrefix)
+
+	return count
+}
+
+// RegisterTagPrefixCounter starts counting the stored transactions with a tag that starts with the given tag prefix.
+// the existing transactions are counted once, afterwards the counter is updated if tags are stored or deleted.
+func RegisterTagPrefixCounter(tagPrefix trinary.Trytes) {
+	tagPrefixCountersLock.Lock()
+	defer tagPrefixCountersLock.Unlock()
+
+	if _, exists := tagPrefixCounters[tagPrefix]; exists {
+		return
+	}
+
+	tagPrefixCounters[tagPrefix] = atomic.NewInt64(int64(CountTagPrefixHashes(tagPrefix, 0)))
+}
+
+// TagPrefixCount returns the amount of stored transactions with a tag that starts with the given registered tag prefix.
+func TagPrefixCount(tagPrefix trinary.Trytes) int {
+	tagPrefixCountersLock.RLock()
+	defer tagPrefixCountersLock.RUnlock()
+
+	counter, exists := tagPrefixCounters[tagPrefix]
+	if !exists {
+		return 0
+	}
+	return int(counter.Load())
+}
+
+// tagPrefixCountersForTag returns the counters of the registered prefixes which match the given tag.
+// tagPrefixCountersLock has to be held by the caller.
+func tagPrefixCountersForTag(txTag hornet.Hash) []*atomic.Int64 {
+	if len(tagPrefixCounters) == 0 {
+		return nil
+	}
+
+	var counters []*atomic.Int64
+	tagTrytes := hornet.Hash(txTag[:17]).Trytes()
+	for tagPrefix, counter := range tagPrefixCounters {
+		if strings.HasPrefix(tagTrytes, tagPrefix) {
+			counters = append(counters, counter)
+		}
+	}
+	return counters
+}
+
 // ContainsTag returns if the given tag exists in the cache/persistence layer.
 func ContainsTag(txTag hornet.Hash, txHash hornet.Hash) bool {
 	return tagsStorage.Contains(append(txTag, txHash...))
@@ -91,13 +165,49 @@ func ForEachTag(consumer TagConsumer, skipCache bool) {
 
 // tag +1
 func StoreTag(txTag hornet.Hash, txHash hornet.Hash) *CachedTag {
+	tagPrefixCountersLock.RLock()
+	defer tagPrefixCountersLock.RUnlock()
+
 	tag := hornet.NewTag(txTag[:17], txHash[:49])
-	return &CachedTag{CachedObject: tagsStorage.Store(tag)}
+
+	counters := tagPrefixCountersForTag(txTag)
+	if len(counters) == 0 {
+		return &CachedTag{CachedObject: tagsStorage.Store(tag)}
+	}
+
+	// only tags which didn't exist before are counted
+	cachedTag, stored := tagsStorage.StoreIfAbsent(tag)
+	if !stored {
+		return &CachedTag{CachedObject: tagsStorage.Load(tag.ObjectStorageKey())}
+	}
+
+	for _, counter := range counters {
+		counter.Inc()
+	}
+	return &CachedTag{CachedObject: cachedTag}
 }
 
 // tag +-0
 func DeleteTag(txTag hornet.Hash, txHash hornet.Hash) {
-	tagsStorage.Delete(append(txTag[:17], txHash[:49]...))
+	tagPrefixCountersLock.RLock()
+	defer tagPrefixCountersLock.RUnlock()
+
+	key := append(txTag[:17], txHash[:49]...)
+
+	counters := tagPrefixCountersForTag(txTag)
+	if len(counters) == 0 {
+		tagsStorage.Delete(key)
+		return
+	}
+
+	// only tags which really existed are subtracted
+	if !tagsStorage.DeleteIfPresent(key) {
+		return
+	}
+
+	for _, counter := range counters {
+		counter.Dec()
+	}
 }
 
 func ShutdownTagsStorage() {
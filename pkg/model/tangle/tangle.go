@@ -2,8 +2,11 @@ package tangle
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path"
+	"sync"
+	"time"
 
 	"go.etcd.io/bbolt"
 
@@ -17,6 +20,10 @@ const (
 	TangleDbFilename         = "tangle.db"
 	SnapshotDbFilename       = "snapshot.db"
 	SpentAddressesDbFilename = "spent.db"
+
+	// databaseBucketStatsCacheTTL is the time the bucket statistics of a database are reused,
+	// since collecting them walks all pages of the database.
+	databaseBucketStatsCacheTTL = 5 * time.Minute
 )
 
 var (
@@ -26,8 +33,17 @@ var (
 	spentDb    *bbolt.DB
 
 	ErrNothingToCleanUp = errors.New("Nothing to clean up in the databases")
+
+	// databaseBucketStatsCache holds the latest bucket statistics per database file.
+	databaseBucketStatsCache     = make(map[string]*cachedDatabaseBucketStats)
+	databaseBucketStatsCacheLock sync.Mutex
 )
 
+type cachedDatabaseBucketStats struct {
+	buckets     []*DatabaseBucketStats
+	collectedAt time.Time
+}
+
 func boltDB(directory string, filename string) *bbolt.DB {
 	opts := &bbolt.Options{
 		NoSync: true,
@@ -158,3 +174,156 @@ func GetDatabaseSizes() (tangle int64, snapshot int64, spent int64) {
 
 	return
 }
+
+// DatabaseBucketStats holds the statistics of a single bucket of a database.
+type DatabaseBucketStats struct {
+	// Name is the name of the bucket.
+	Name string
+	// KeyCount is the amount of keys in the bucket.
+	KeyCount int
+	// InUseBytes is the amount of bytes actually used by the pages of the bucket.
+	InUseBytes int
+	// AllocatedBytes is the amount of bytes allocated by the pages of the bucket.
+	AllocatedBytes int
+}
+
+// DatabaseStats holds the statistics of a database.
+type DatabaseStats struct {
+	// Name is the filename of the database.
+	Name string
+	// Size is the size of the database file.
+	Size int64
+	// FreePageCount is the amount of free pages on the freelist.
+	FreePageCount int
+	// PendingPageCount is the amount of pending pages on the freelist.
+	PendingPageCount int
+	// FreeAllocBytes is the amount of bytes allocated in free pages.
+	FreeAllocBytes int
+	// OpenReadTxCount is the amount of currently open read transactions.
+	OpenReadTxCount int
+	// Buckets are the statistics of the buckets in the database.
+	Buckets []*DatabaseBucketStats
+}
+
+// bucketName returns a human readable name for the given bucket.
+func bucketName(name []byte) string {
+	if len(name) != 1 {
+		return fmt.Sprintf("%x", name)
+	}
+
+	switch name[0] {
+	case StorePrefixHealth:
+		return "health"
+	case StorePrefixTransactions:
+		return "transactions"
+	case StorePrefixTransactionMetadata:
+		return "transactionMetadata"
+	case StorePrefixBundleTransactions:
+		return "bundleTransactions"
+	case StorePrefixBundles:
+		return "bundles"
+	case StorePrefixAddresses:
+		return "addresses"
+	case StorePrefixMilestones:
+		return "milestones"
+	case StorePrefixLedgerState:
+		return "ledgerState"
+	case StorePrefixLedgerBalance:
+		return "ledgerBalance"
+	case StorePrefixLedgerDiff:
+		return "ledgerDiff"
+	case StorePrefixApprovers:
+		return "approvers"
+	case StorePrefixTags:
+		return "tags"
+	case StorePrefixSnapshot:
+		return "snapshot"
+	case StorePrefixSnapshotLedger:
+		return "snapshotLedger"
+	case StorePrefixUnconfirmedTransactions:
+		return "unconfirmedTransactions"
+	case StorePrefixSpentAddresses:
+		return "spentAddresses"
+	case StorePrefixAutopeering:
+		return "autopeering"
+	default:
+		return fmt.Sprintf("%x", name)
+	}
+}
+
+func getDatabaseStats(db *bbolt.DB, filename string) (*DatabaseStats, error) {
+
+	dbStats := db.Stats()
+
+	stats := &DatabaseStats{
+		Name:             filename,
+		FreePageCount:    dbStats.FreePageN,
+		PendingPageCount: dbStats.PendingPageN,
+		FreeAllocBytes:   dbStats.FreeAlloc,
+		OpenReadTxCount:  dbStats.OpenTxN,
+	}
+
+	if dbFile, err := os.Stat(path.Join(dbDir, filename)); err == nil {
+		stats.Size = dbFile.Size()
+	}
+
+	buckets, err := getDatabaseBucketStats(db, filename)
+	if err != nil {
+		return nil, err
+	}
+	stats.Buckets = buckets
+
+	return stats, nil
+}
+
+// getDatabaseBucketStats returns the statistics of all buckets of the database.
+// the statistics are cached for databaseBucketStatsCacheTTL, so frequent API calls don't keep walking the whole database.
+func getDatabaseBucketStats(db *bbolt.DB, filename string) ([]*DatabaseBucketStats, error) {
+	databaseBucketStatsCacheLock.Lock()
+	defer databaseBucketStatsCacheLock.Unlock()
+
+	if cached, exists := databaseBucketStatsCache[filename]; exists && time.Since(cached.collectedAt) < databaseBucketStatsCacheTTL {
+		return cached.buckets, nil
+	}
+
+	buckets := make([]*DatabaseBucketStats, 0)
+	if err := db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bbolt.Bucket) error {
+			bucketStats := bucket.Stats()
+			buckets = append(buckets, &DatabaseBucketStats{
+				Name:           bucketName(name),
+				KeyCount:       bucketStats.KeyN,
+				InUseBytes:     bucketStats.BranchInuse + bucketStats.LeafInuse,
+				AllocatedBytes: bucketStats.BranchAlloc + bucketStats.LeafAlloc,
+			})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	databaseBucketStatsCache[filename] = &cachedDatabaseBucketStats{buckets: buckets, collectedAt: time.Now()}
+
+	return buckets, nil
+}
+
+// GetDatabaseStats returns the statistics of the different databases.
+func GetDatabaseStats() ([]*DatabaseStats, error) {
+
+	tangleStats, err := getDatabaseStats(tangleDb, TangleDbFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotStats, err := getDatabaseStats(snapshotDb, SnapshotDbFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	spentStats, err := getDatabaseStats(spentDb, SpentAddressesDbFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DatabaseStats{tangleStats, snapshotStats, spentStats}, nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"go.uber.org/atomic"
+
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/objectstorage"
 
@@ -15,6 +17,10 @@ import (
 var (
 	txStorage       *objectstorage.ObjectStorage
 	metadataStorage *objectstorage.ObjectStorage
+
+	// the amount of transactions in the cache/persistence layer.
+	// it is persisted at shutdown and only counted at startup if the node didn't shut down cleanly.
+	storedTransactionsCount atomic.Int64
 )
 
 func TransactionCaller(handler interface{}, params ...interface{}) {
@@ -161,6 +167,11 @@ func GetTransactionStorageSize() int {
 	return txStorage.GetSize()
 }
 
+// GetStoredTransactionsCount returns the amount of transactions in the cache/persistence layer.
+func GetStoredTransactionsCount() int64 {
+	return storedTransactionsCount.Load()
+}
+
 func configureTransactionStorage(store kvstore.KVStore, opts profile.CacheOpts) {
 
 	txStorage = objectstorage.New(
@@ -188,6 +199,16 @@ func configureTransactionStorage(store kvstore.KVStore, opts profile.CacheOpts)
 				MaxConsumerHoldTime:   time.Duration(opts.LeakDetectionOptions.MaxConsumerHoldTimeSec) * time.Second,
 			}),
 	)
+
+	count, exists := loadStoredTransactionsCount()
+	if !exists {
+		// count the stored transactions once, the counter is updated on store and delete afterwards
+		txStorage.ForEachKeyOnly(func(_ []byte) bool {
+			count++
+			return true
+		}, true)
+	}
+	storedTransactionsCount.Store(count)
 }
 
 // tx +1
@@ -281,6 +302,7 @@ func StoreTransactionIfAbsent(transaction *hornet.Transaction) (cachedTx *Cached
 
 		transaction.Persist()
 		transaction.SetModified()
+		storedTransactionsCount.Inc()
 		return transaction
 	})
 
@@ -314,7 +336,11 @@ func ForEachTransactionMetadataHash(consumer TransactionHashConsumer, skipCache
 func DeleteTransaction(txHash hornet.Hash) {
 	// metadata has to be deleted before the tx, otherwise we could run into a data race in the object storage
 	metadataStorage.Delete(txHash)
-	txStorage.Delete(txHash)
+
+	// only transactions which really existed are subtracted
+	if txStorage.DeleteIfPresent(txHash) {
+		storedTransactionsCount.Dec()
+	}
 }
 
 // DeleteTransactionMetadata deletes the metadata in the cache/persistence layer.
@@ -325,6 +351,9 @@ func DeleteTransactionMetadata(txHash hornet.Hash) {
 func ShutdownTransactionStorage() {
 	txStorage.Shutdown()
 	metadataStorage.Shutdown()
+
+	// all pending changes were written, so the counter matches the persistence layer
+	persistStoredTransactionsCount(storedTransactionsCount.Load())
 }
 
 func FlushTransactionStorage() {
@@ -42,6 +42,7 @@ func (m *Manager) setupHandshakeEventHandlers(p *peer.Peer) {
 
 		// first receive timestamp has to be set here, otherwise we could falsely drop the peer if the heartbeat is checked
 		p.HeartbeatReceivedTime = time.Now()
+		p.SetConnectedTime(time.Now())
 
 		m.Events.PeerConnected.Trigger(p)
 	}))
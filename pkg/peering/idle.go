@@ -0,0 +1,33 @@
+package peering
+
+import (
+	"time"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+// DisconnectIdlePeers closes the connections of all connected peers which didn't send any transactions
+// or requests within the given duration and returns the disconnected peers.
+// the peers are not removed, so static peers are moved back into the reconnect pool.
+func (m *Manager) DisconnectIdlePeers(maxIdle time.Duration) []*peer.Peer {
+	var idlePeers []*peer.Peer
+
+	m.ForAllConnected(func(p *peer.Peer) bool {
+		if p.Conn == nil {
+			// connection is not established yet
+			return true
+		}
+		if p.IdleDuration() >= maxIdle {
+			idlePeers = append(idlePeers, p)
+		}
+		return true
+	})
+
+	for _, p := range idlePeers {
+		if err := p.Conn.Close(); err != nil {
+			m.Events.Error.Trigger(err)
+		}
+	}
+
+	return idlePeers
+}
@@ -4,7 +4,10 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
+	syncatomic "sync/atomic"
 	"time"
+	"unsafe"
 
 	"go.uber.org/atomic"
 
@@ -112,12 +115,24 @@ type Peer struct {
 	MoveBackToReconnectPool bool
 	// Whether the peer is a duplicate, as it is already connected.
 	Duplicate bool
+	// Time the handshake with the peer was completed (unix nanoseconds).
+	// it is set after the peer was added to the connected peers, so it is accessed atomically.
+	connectedTime atomic.Int64
 	// The peer's latest heartbeat message.
 	LatestHeartbeat *sting.Heartbeat
 	// Time the last heartbeat was received.
 	HeartbeatReceivedTime time.Time
 	// Time the last heartbeat was sent.
 	HeartbeatSentTime time.Time
+	// The last measured round-trip time to the peer.
+	latestPingRTT time.Duration
+	// Time the last round-trip time was measured.
+	latestPingTime time.Time
+	// Lock for the latest ping, since it is read by the API and the peering manager.
+	latestPingLock sync.RWMutex
+	// The ping which waits for the reply of the peer (*PendingPing).
+	// it is checked for every received transaction, so it is accessed atomically instead of being guarded by a lock.
+	pendingPing unsafe.Pointer
 	// Holds the autopeering info if this peer was added via autopeering.
 	Autopeering *peer.Peer
 	// A channel which contains messages to be sent to the given peer.
@@ -127,10 +142,14 @@ type Peer struct {
 	Disconnected bool
 	// Events happening on the peer.
 	Events Events
+	// lock for the autopeer stale check, since the metrics can be reset by the API
+	staledAutopeerCheckLock sync.Mutex
 	// The last amount of sent transactions at the last autopeer stale check
 	staledAutopeerCheckLastSentPackets uint32
 	// The last amount of dropped packets at the last autopeer stale check
 	staledAutopeerCheckLastDroppedPackets uint32
+	// Time the last transaction or request was received from the peer (unix nanoseconds).
+	lastActivityTime atomic.Int64
 }
 
 // IsInbound tells whether the peer's connection was inbound.
@@ -150,6 +169,9 @@ func (p *Peer) CheckStaledAutopeer(maxPercentage int) (bool, float32) {
 		return false, 0
 	}
 
+	p.staledAutopeerCheckLock.Lock()
+	defer p.staledAutopeerCheckLock.Unlock()
+
 	if p.staledAutopeerCheckLastSentPackets == 0 && p.staledAutopeerCheckLastDroppedPackets == 0 {
 		// initialize the check for the first time
 		p.staledAutopeerCheckLastSentPackets = p.Metrics.SentPackets.Load()
@@ -174,6 +196,91 @@ func (p *Peer) CheckStaledAutopeer(maxPercentage int) (bool, float32) {
 	return percentageDropped >= float32(maxPercentage), percentageDropped
 }
 
+// RecordActivity marks that a transaction or request was received from the peer.
+// heartbeats are not recorded, since they are also sent by peers which don't exchange any data.
+func (p *Peer) RecordActivity() {
+	p.lastActivityTime.Store(time.Now().UnixNano())
+}
+
+// IdleDuration returns the time since the peer sent the last transaction or request.
+// if the peer didn't send any data yet, the duration is measured from the completed handshake.
+func (p *Peer) IdleDuration() time.Duration {
+	lastActivity := p.lastActivityTime.Load()
+	if lastActivity == 0 {
+		lastActivity = p.connectedTime.Load()
+	}
+	if lastActivity == 0 {
+		// the handshake was not completed yet
+		return 0
+	}
+	return time.Since(time.Unix(0, lastActivity))
+}
+
+// SetConnectedTime sets the time the handshake with the peer was completed.
+func (p *Peer) SetConnectedTime(connectedTime time.Time) {
+	p.connectedTime.Store(connectedTime.UnixNano())
+}
+
+// ConnectedTime returns the time the handshake with the peer was completed.
+// the returned time is zero if the handshake was not completed yet.
+func (p *Peer) ConnectedTime() time.Time {
+	connectedTime := p.connectedTime.Load()
+	if connectedTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, connectedTime)
+}
+
+// SetLatestPing sets the last measured round-trip time to the peer.
+func (p *Peer) SetLatestPing(rtt time.Duration) {
+	p.latestPingLock.Lock()
+	defer p.latestPingLock.Unlock()
+
+	p.latestPingRTT = rtt
+	p.latestPingTime = time.Now()
+}
+
+// LatestPing returns the last measured round-trip time to the peer and the time it was measured.
+func (p *Peer) LatestPing() (rtt time.Duration, measuredTime time.Time) {
+	p.latestPingLock.RLock()
+	defer p.latestPingLock.RUnlock()
+
+	return p.latestPingRTT, p.latestPingTime
+}
+
+// LatestPingRTT returns the last measured round-trip time to the peer.
+func (p *Peer) LatestPingRTT() time.Duration {
+	rtt, _ := p.LatestPing()
+	return rtt
+}
+
+// PendingPing is a ping which waits for the reply of the peer.
+type PendingPing struct {
+	// ExpectedTxBytes is the raw transaction the peer has to send back.
+	ExpectedTxBytes []byte
+	// SentTime is the time the ping was sent.
+	SentTime time.Time
+	// ReplyChan receives the measured round-trip time.
+	ReplyChan chan time.Duration
+}
+
+// SetPendingPing sets the ping which waits for the reply of the peer.
+// it returns false if there is already a ping in progress.
+func (p *Peer) SetPendingPing(ping *PendingPing) bool {
+	return syncatomic.CompareAndSwapPointer(&p.pendingPing, nil, unsafe.Pointer(ping))
+}
+
+// PendingPing returns the ping which waits for the reply of the peer or nil.
+func (p *Peer) PendingPing() *PendingPing {
+	return (*PendingPing)(syncatomic.LoadPointer(&p.pendingPing))
+}
+
+// ClearPendingPing removes the given ping if it is still pending.
+// it returns false if the ping was already removed.
+func (p *Peer) ClearPendingPing(ping *PendingPing) bool {
+	return syncatomic.CompareAndSwapPointer(&p.pendingPing, unsafe.Pointer(ping), nil)
+}
+
 // EnqueueForSending enqueues the given data to be sent to the peer.
 // If it can't because the send queue is over capacity, the message gets dropped.
 func (p *Peer) EnqueueForSending(data []byte) {
@@ -208,11 +315,23 @@ func (p *Peer) Info() *Info {
 		NumberOfSentMilestoneReq:       p.Metrics.SentMilestoneRequests.Load(),
 		NumberOfSentHeartbeats:         p.Metrics.SentHeartbeats.Load(),
 		NumberOfDroppedSentPackets:     p.Metrics.DroppedPackets.Load(),
+		LatestPingRTTMs:                p.LatestPingRTT().Milliseconds(),
+		IdleSeconds:                    int64(p.IdleDuration().Seconds()),
 		ConnectionType:                 "tcp",
 		Connected:                      false,
 		Autopeered:                     false,
 		AutopeeringID:                  "",
 	}
+	if heartbeat := p.LatestHeartbeat; heartbeat != nil {
+		info.Heartbeat = &HeartbeatInfo{
+			SolidMilestoneIndex:  heartbeat.SolidMilestoneIndex,
+			PrunedMilestoneIndex: heartbeat.PrunedMilestoneIndex,
+			LatestMilestoneIndex: heartbeat.LatestMilestoneIndex,
+			ConnectedNeighbors:   heartbeat.ConnectedNeighbors,
+			SyncedNeighbors:      heartbeat.SyncedNeighbors,
+			ReceivedTime:         p.HeartbeatReceivedTime.Unix(),
+		}
+	}
 	if p.Autopeering != nil {
 		info.Autopeered = true
 		info.AutopeeringID = p.Autopeering.ID().String()
@@ -220,6 +339,35 @@ func (p *Peer) Info() *Info {
 	return info
 }
 
+// ResetMetrics zeroes the metrics of the peer and returns a snapshot of the peer
+// containing the metrics as they were before the reset.
+func (p *Peer) ResetMetrics() *Info {
+	info := p.Info()
+	info.NumberOfAllTransactions = p.Metrics.ReceivedTransactions.Swap(0)
+	info.NumberOfNewTransactions = p.Metrics.NewTransactions.Swap(0)
+	info.NumberOfKnownTransactions = p.Metrics.KnownTransactions.Swap(0)
+	info.NumberOfStaleTransactions = p.Metrics.StaleTransactions.Swap(0)
+	info.NumberOfReceivedTransactionReq = p.Metrics.ReceivedTransactionRequests.Swap(0)
+	info.NumberOfReceivedMilestoneReq = p.Metrics.ReceivedMilestoneRequests.Swap(0)
+	info.NumberOfReceivedHeartbeats = p.Metrics.ReceivedHeartbeats.Swap(0)
+	info.NumberOfSentTransactions = p.Metrics.SentTransactions.Swap(0)
+	info.NumberOfSentTransactionsReq = p.Metrics.SentTransactionRequests.Swap(0)
+	info.NumberOfSentMilestoneReq = p.Metrics.SentMilestoneRequests.Swap(0)
+	info.NumberOfSentHeartbeats = p.Metrics.SentHeartbeats.Swap(0)
+
+	p.staledAutopeerCheckLock.Lock()
+	defer p.staledAutopeerCheckLock.Unlock()
+
+	info.NumberOfSentPackets = p.Metrics.SentPackets.Swap(0)
+	info.NumberOfDroppedSentPackets = p.Metrics.DroppedPackets.Swap(0)
+
+	// restart the stale check, otherwise the reset would be detected as an overflow
+	p.staledAutopeerCheckLastSentPackets = 0
+	p.staledAutopeerCheckLastDroppedPackets = 0
+
+	return info
+}
+
 // HasDataFor tells whether the peer given the latest heartbeat message, has the cone data for the given milestone.
 // Returns false if no heartbeat message was received yet.
 func (p *Peer) HasDataFor(index milestone.Index) bool {
@@ -303,8 +451,26 @@ type Info struct {
 	NumberOfSentMilestoneReq       uint32 `json:"numberOfSentMilestoneReq"`
 	NumberOfSentHeartbeats         uint32 `json:"numberOfSentHeartbeats"`
 	NumberOfDroppedSentPackets     uint32 `json:"numberOfDroppedSentPackets"`
+	LatestPingRTTMs                int64  `json:"latestPingRttMs"`
+	IdleSeconds                    int64  `json:"idleSeconds"`
 	ConnectionType                 string `json:"connectionType"`
 	Connected                      bool   `json:"connected"`
 	Autopeered                     bool   `json:"autopeered"`
 	AutopeeringID                  string `json:"autopeeringId,omitempty"`
+	DNSSeeded                      bool   `json:"dnsSeeded"`
+	// Score is the usefulness of a connected peer in comparison to the other connected peers.
+	Score float64 `json:"score"`
+	// Heartbeat is the latest heartbeat received from the peer (nil if no heartbeat was received yet).
+	Heartbeat *HeartbeatInfo `json:"heartbeat,omitempty"`
+}
+
+// HeartbeatInfo acts as a static snapshot of the latest heartbeat received from a peer.
+type HeartbeatInfo struct {
+	SolidMilestoneIndex  milestone.Index `json:"solidMilestoneIndex"`
+	PrunedMilestoneIndex milestone.Index `json:"prunedMilestoneIndex"`
+	LatestMilestoneIndex milestone.Index `json:"latestMilestoneIndex"`
+	ConnectedNeighbors   int             `json:"connectedNeighbors"`
+	SyncedNeighbors      int             `json:"syncedNeighbors"`
+	// ReceivedTime is the unix timestamp when the heartbeat was received.
+	ReceivedTime int64 `json:"receivedTime"`
 }
@@ -0,0 +1,127 @@
+package peering
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+const (
+	// MaxRecentErrorsPerPeer defines the maximum amount of recent errors kept per peer.
+	MaxRecentErrorsPerPeer = 20
+	// maxPeersWithRecentErrors defines the maximum amount of peers for which recent errors are kept.
+	// if the limit is reached, the peer with the oldest last error is evicted.
+	maxPeersWithRecentErrors = 100
+)
+
+// PeerErrorType defines the type of a gossip protocol error caused by a peer.
+type PeerErrorType string
+
+const (
+	// PeerErrorInvalidTransaction is recorded if a peer sent an invalid transaction.
+	PeerErrorInvalidTransaction PeerErrorType = "invalidTransaction"
+	// PeerErrorInvalidRequest is recorded if a peer sent an invalid request.
+	PeerErrorInvalidRequest PeerErrorType = "invalidRequest"
+	// PeerErrorProtocol is recorded if the protocol of a peer failed, e.g. because of a malformed message.
+	PeerErrorProtocol PeerErrorType = "protocol"
+)
+
+// PeerError is a gossip protocol error caused by a peer.
+type PeerError struct {
+	Type      PeerErrorType `json:"type"`
+	Error     string        `json:"error"`
+	Timestamp int64         `json:"timestamp"`
+}
+
+// recentPeerErrors are the most recent errors of a peer.
+type recentPeerErrors struct {
+	errors    *utils.RingBuffer
+	lastError time.Time
+}
+
+func newRecentPeerErrors() *recentPeerErrors {
+	return &recentPeerErrors{errors: utils.NewRingBuffer(MaxRecentErrorsPerPeer)}
+}
+
+func (r *recentPeerErrors) add(peerErr *PeerError) {
+	r.errors.Add(peerErr)
+	r.lastError = time.Unix(peerErr.Timestamp, 0)
+}
+
+// newestFirst returns the errors ordered from the newest to the oldest.
+func (r *recentPeerErrors) newestFirst() []*PeerError {
+	result := make([]*PeerError, 0, r.errors.Len())
+	r.errors.ForEachNewestFirst(func(element interface{}) bool {
+		result = append(result, element.(*PeerError))
+		return true
+	})
+	return result
+}
+
+// peerErrorStore holds the recent errors of peers by their ID.
+// the errors are kept independently of the peer instances, since misbehaving peers get disconnected.
+type peerErrorStore struct {
+	sync.Mutex
+	peers map[string]*recentPeerErrors
+}
+
+// RecordPeerError records a gossip protocol error caused by the given peer.
+func (m *Manager) RecordPeerError(p *peer.Peer, errType PeerErrorType, err error) {
+	m.peerErrors.Lock()
+	defer m.peerErrors.Unlock()
+
+	if m.peerErrors.peers == nil {
+		m.peerErrors.peers = make(map[string]*recentPeerErrors)
+	}
+
+	recentErrors, exists := m.peerErrors.peers[p.ID]
+	if !exists {
+		if len(m.peerErrors.peers) >= maxPeersWithRecentErrors {
+			m.evictOldestPeerErrors()
+		}
+		recentErrors = newRecentPeerErrors()
+		m.peerErrors.peers[p.ID] = recentErrors
+	}
+
+	recentErrors.add(&PeerError{Type: errType, Error: err.Error(), Timestamp: time.Now().Unix()})
+}
+
+// evictOldestPeerErrors removes the recent errors of the peer with the oldest last error.
+// the peerErrors lock has to be held by the caller.
+func (m *Manager) evictOldestPeerErrors() {
+	var oldestID string
+	var oldestTime time.Time
+	for id, recentErrors := range m.peerErrors.peers {
+		if oldestID == "" || recentErrors.lastError.Before(oldestTime) {
+			oldestID = id
+			oldestTime = recentErrors.lastError
+		}
+	}
+	delete(m.peerErrors.peers, oldestID)
+}
+
+// PeerErrors returns the recent errors of the peer with the given ID, ordered from the newest to the oldest.
+func (m *Manager) PeerErrors(id string) []*PeerError {
+	m.peerErrors.Lock()
+	defer m.peerErrors.Unlock()
+
+	recentErrors, exists := m.peerErrors.peers[id]
+	if !exists {
+		return []*PeerError{}
+	}
+	return recentErrors.newestFirst()
+}
+
+// AllPeerErrors returns the recent errors of all peers by their ID.
+func (m *Manager) AllPeerErrors() map[string][]*PeerError {
+	m.peerErrors.Lock()
+	defer m.peerErrors.Unlock()
+
+	result := make(map[string][]*PeerError, len(m.peerErrors.peers))
+	for id, recentErrors := range m.peerErrors.peers {
+		result[id] = recentErrors.newestFirst()
+	}
+	return result
+}
@@ -76,6 +76,7 @@ func NewManager(opts Options, peers ...*config.PeerConfig) *Manager {
 		reconnect: map[string]*reconnectinfo{},
 		whitelist: map[string]*autopeering.Peer{},
 		blacklist: map[string]struct{}{},
+		dnsSeeded: map[string]struct{}{},
 		Opts:      opts,
 	}
 	m.moveInitialPeersToReconnectPool(peers)
@@ -108,6 +109,10 @@ type Manager struct {
 	blacklistMu sync.Mutex
 	// used to enforce one handshake verification at a time.
 	handshakeVerifyMu sync.Mutex
+	// holds the origin addresses of the peers which were added via DNS seeding.
+	dnsSeeded map[string]struct{}
+	// holds the recent gossip protocol errors of peers.
+	peerErrors peerErrorStore
 
 	// only used by ConnectedAndSyncedPeerCount
 	connectedNeighborsCount  uint8
@@ -131,6 +136,8 @@ type Options struct {
 	AcceptAnyPeer bool
 	// Inbound connection bind address.
 	BindAddress string
+	// The weights used to score the connected peers.
+	ScoreWeights ScoreWeights
 }
 
 // Events defines events fired regarding peering.
@@ -323,9 +330,12 @@ func (m *Manager) PeerInfos() []*peer.Info {
 	m.RLock()
 	defer m.RUnlock()
 	infos := make([]*peer.Info, 0)
+	scores := m.peerScoresWithoutLocking()
 	for _, p := range m.connected {
 		info := p.Info()
 		info.Connected = true
+		info.Score = scores[p.ID]
+		_, info.DNSSeeded = m.dnsSeeded[info.DomainWithPort]
 		infos = append(infos, info)
 	}
 	for _, reconnectInfo := range m.reconnect {
@@ -345,11 +355,45 @@ func (m *Manager) PeerInfos() []*peer.Info {
 			info.Autopeered = true
 			info.AutopeeringID = reconnectInfo.Autopeering.ID().String()
 		}
+		_, info.DNSSeeded = m.dnsSeeded[originAddr.String()]
 		infos = append(infos, info)
 	}
 	return infos
 }
 
+// ResetPeerMetrics zeroes the gossip metrics of the connected peer with the given ID without disconnecting it.
+// It returns a snapshot of the peer containing the metrics as they were before the reset.
+func (m *Manager) ResetPeerMetrics(id string) (*peer.Info, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	p, exists := m.connected[id]
+	if !exists {
+		// the peer could also be identified by its origin address, i.e node.example.com:15600
+		for _, connectedPeer := range m.connected {
+			if connectedPeer.InitAddress != nil && connectedPeer.InitAddress.String() == id {
+				p = connectedPeer
+				exists = true
+				break
+			}
+		}
+	}
+
+	if !exists {
+		return nil, ErrUnknownPeerID
+	}
+
+	// the score is based on the metrics before the reset
+	score := m.peerScoresWithoutLocking()[p.ID]
+
+	info := p.ResetMetrics()
+	info.Connected = true
+	info.Score = score
+	_, info.DNSSeeded = m.dnsSeeded[info.DomainWithPort]
+
+	return info, nil
+}
+
 // PeerCount returns the current count of connected and in the reconnect pool residing peers.
 func (m *Manager) PeerCount() int {
 	m.RLock()
@@ -445,6 +489,7 @@ func (m *Manager) SetupEventHandlers(p *peer.Peer) {
 		if p.Disconnected {
 			return
 		}
+		m.RecordPeerError(p, PeerErrorProtocol, err)
 		m.Events.Error.Trigger(err)
 		if closeErr := p.Conn.Close(); closeErr != nil {
 			m.Events.Error.Trigger(closeErr)
@@ -559,6 +604,26 @@ func (m *Manager) Add(addr string, preferIPv6 bool, alias string, autoPeer ...*a
 	return nil
 }
 
+// AddDNSSeeded adds a new peer which was discovered via DNS seeding.
+// The peer is handled like a manually added peer, but it is tagged as DNS-seeded.
+func (m *Manager) AddDNSSeeded(addr string, preferIPv6 bool) error {
+
+	originAddr, err := iputils.ParseOriginAddress(addr)
+	if err != nil {
+		return fmt.Errorf("invalid peer address '%s': %w", addr, err)
+	}
+
+	if err := m.Add(addr, preferIPv6, ""); err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	m.dnsSeeded[originAddr.String()] = struct{}{}
+
+	return nil
+}
+
 // Remove tries to remove and close any open connections for peers which are identifiable through the given ID.
 func (m *Manager) Remove(id string) error {
 	originAddr, err := iputils.ParseOriginAddress(id)
@@ -568,6 +633,8 @@ func (m *Manager) Remove(id string) error {
 	m.Lock()
 	defer m.Unlock()
 
+	delete(m.dnsSeeded, originAddr.String())
+
 	// make sure the peer is removed by all its possible IDs by going
 	// through each resolved IP address from the lookup
 	delete(m.reconnect, id)
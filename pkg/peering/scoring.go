@@ -0,0 +1,132 @@
+package peering
+
+import (
+	"time"
+
+	"github.com/gohornet/hornet/pkg/peering/peer"
+)
+
+const (
+	// the latency score of peers whose round-trip time was not measured yet.
+	unknownLatencyScore = 0.5
+)
+
+// ScoreWeights defines the weights of the metrics the score of a peer is derived from.
+type ScoreWeights struct {
+	// Data is the weight of the rate of new transactions received from the peer.
+	Data float64
+	// Latency is the weight of the latest measured round-trip time to the peer.
+	Latency float64
+	// Uptime is the weight of the time the peer is connected.
+	Uptime float64
+}
+
+// PeerScores returns the scores of all connected peers, keyed by peer ID.
+// every metric is normalized in relation to the best connected peer, so a score is between 0 and
+// the sum of the weights and is only meaningful in comparison to the scores of the other peers.
+func (m *Manager) PeerScores() map[string]float64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.peerScoresWithoutLocking()
+}
+
+func (m *Manager) peerScoresWithoutLocking() map[string]float64 {
+
+	type peerMetrics struct {
+		dataRate float64
+		rtt      time.Duration
+		uptime   time.Duration
+	}
+
+	var maxDataRate float64
+	var minRTT, maxUptime time.Duration
+
+	metrics := make(map[string]*peerMetrics)
+	for id, p := range m.connected {
+		connectedTime := p.ConnectedTime()
+		if !p.Handshaked() || connectedTime.IsZero() {
+			continue
+		}
+
+		uptime := time.Since(connectedTime)
+
+		pm := &peerMetrics{rtt: p.LatestPingRTT(), uptime: uptime}
+		if uptime > 0 {
+			pm.dataRate = float64(p.Metrics.NewTransactions.Load()) / uptime.Seconds()
+		}
+		metrics[id] = pm
+
+		if pm.dataRate > maxDataRate {
+			maxDataRate = pm.dataRate
+		}
+		if pm.rtt > 0 && (minRTT == 0 || pm.rtt < minRTT) {
+			minRTT = pm.rtt
+		}
+		if pm.uptime > maxUptime {
+			maxUptime = pm.uptime
+		}
+	}
+
+	scores := make(map[string]float64, len(metrics))
+	for id, pm := range metrics {
+		var score float64
+		if maxDataRate > 0 {
+			score += m.Opts.ScoreWeights.Data * pm.dataRate / maxDataRate
+		}
+		if pm.rtt > 0 {
+			score += m.Opts.ScoreWeights.Latency * float64(minRTT) / float64(pm.rtt)
+		} else {
+			score += m.Opts.ScoreWeights.Latency * unknownLatencyScore
+		}
+		if maxUptime > 0 {
+			score += m.Opts.ScoreWeights.Uptime * float64(pm.uptime) / float64(maxUptime)
+		}
+		scores[id] = score
+	}
+
+	return scores
+}
+
+// PruneLowestScoredAutopeer removes the autopeered peer with the lowest score if the amount
+// of connected autopeered peers reached the given maximum, to free the slot for a potentially better peer.
+// peers which are connected for less than the grace period are not pruned, since their metrics are not meaningful yet.
+// static peers are never pruned. returns the removed peer and its score, or nil if no peer was removed.
+func (m *Manager) PruneLowestScoredAutopeer(maxAutopeers int, gracePeriod time.Duration) (*peer.Peer, float64) {
+
+	var lowestPeer *peer.Peer
+	var lowestScore float64
+
+	m.RLock()
+	scores := m.peerScoresWithoutLocking()
+
+	autopeersCount := 0
+	for id, p := range m.connected {
+		if !p.Handshaked() || p.Autopeering == nil {
+			continue
+		}
+		autopeersCount++
+
+		if connectedTime := p.ConnectedTime(); connectedTime.IsZero() || time.Since(connectedTime) < gracePeriod {
+			continue
+		}
+
+		if score := scores[id]; lowestPeer == nil || score < lowestScore {
+			lowestPeer = p
+			lowestScore = score
+		}
+	}
+	m.RUnlock()
+
+	if autopeersCount < maxAutopeers || lowestPeer == nil {
+		// connection slots are not scarce or there is no candidate
+		return nil, 0
+	}
+
+	if err := m.Remove(lowestPeer.ID); err != nil {
+		m.Events.Error.Trigger(err)
+		return nil, 0
+	}
+
+	return lowestPeer, lowestScore
+}
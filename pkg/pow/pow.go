@@ -1,6 +1,7 @@
 package pow
 
 import (
+	"context"
 	"time"
 
 	"github.com/iotaledger/iota.go/pow"
@@ -171,6 +172,39 @@ func (h *Handler) DoPoW(trytes trinary.Trytes, mwm int, parallelism ...int) (non
 	return h.localPoWFunc(trytes, mwm, parallelism...)
 }
 
+// DoPoWWithContext calculates the PoW like DoPoW, but returns the error of the context if it is done before the PoW finished.
+// the amount of concurrent computations is limited by the given semaphore. waiting for a free slot honors the context,
+// but the PoW functions can't be interrupted, so an abandoned computation keeps its slot until it finished in the background.
+// this way abandoned computations can't pile up if clients retry their requests.
+func (h *Handler) DoPoWWithContext(ctx context.Context, semaphore chan struct{}, trytes trinary.Trytes, mwm int, parallelism ...int) (nonce string, err error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case semaphore <- struct{}{}:
+	}
+
+	type powResult struct {
+		nonce string
+		err   error
+	}
+
+	// buffered, so the goroutine doesn't leak if the result is not received anymore
+	resultChan := make(chan *powResult, 1)
+	go func() {
+		defer func() { <-semaphore }()
+
+		nonce, err := h.DoPoW(trytes, mwm, parallelism...)
+		resultChan <- &powResult{nonce: nonce, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-resultChan:
+		return result.nonce, result.err
+	}
+}
+
 // Close closes the PoW handler
 func (h *Handler) Close() {
 	h.powsrvLock.Lock()
@@ -35,6 +35,12 @@ const (
 var (
 	workerCount         = curl.Hasher().BatchSize() * curl.Hasher().WorkerCount()
 	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	// ErrInsufficientMWM is returned if the nonce of a received transaction doesn't fulfill the minimum weight magnitude.
+	ErrInsufficientMWM = errors.New("insufficient minimum weight magnitude")
+	// ErrInvalidMilestoneTransaction is returned if a received transaction belongs to a known invalid milestone.
+	ErrInvalidMilestoneTransaction = errors.New("transaction of an invalid milestone")
+	// ErrKnownInvalidTransaction is returned if a received transaction was already marked as invalid.
+	ErrKnownInvalidTransaction = errors.New("transaction is known to be invalid")
 
 	invalidMilestoneHashes = map[string]struct{}{
 		string(hornet.HashFromHashTrytes("HBXSPG9ISUFPIRLFWEXGEXKEDRXZQMXYQMGHPPHCUNVRHQMRHVEVZIGLZVLAZ9ALHMTYZZBXRHLVA9999")): {},
@@ -139,65 +145,104 @@ func (proc *Processor) Process(p *peer.Peer, msgType message.Type, data []byte)
 // through some other mechanism. This function does not run within the Processor's worker pool.
 // Emits a TransactionProcessed and BroadcastTransaction event if the transaction was processed.
 func (proc *Processor) ValidateTransactionTrytesAndEmit(txTrytes trinary.Trytes) error {
+	tx, txTrits, err := validateTransactionTrytes(txTrytes)
+	if err != nil {
+		return err
+	}
+
+	return proc.CompressAndEmit(tx, txTrits)
+}
+
+// ValidateTransactionTrytesAndStore validates the given transaction trytes which were not received via gossip but
+// through some other mechanism. This function does not run within the Processor's worker pool.
+// Emits only a TransactionProcessed event if the transaction was processed, the transaction is not broadcasted.
+// Neighbors only learn about the transaction if they request it, e.g. because another transaction references it.
+func (proc *Processor) ValidateTransactionTrytesAndStore(txTrytes trinary.Trytes) error {
+	tx, txTrits, err := validateTransactionTrytes(txTrytes)
+	if err != nil {
+		return err
+	}
+
+	hornetTx, err := proc.compress(tx, txTrits)
+	if err != nil {
+		return err
+	}
+
+	proc.Events.TransactionProcessed.Trigger(hornetTx, (*rqueue.Request)(nil), (*peer.Peer)(nil))
+	return nil
+}
+
+// validateTransactionTrytes parses the given transaction trytes and checks the value and the PoW of the transaction.
+func validateTransactionTrytes(txTrytes trinary.Trytes) (*transaction.Transaction, trinary.Trits, error) {
 	if !guards.IsTransactionTrytes(txTrytes) {
-		return consts.ErrInvalidTransactionTrytes
+		return nil, nil, consts.ErrInvalidTransactionTrytes
 	}
 
 	txTrits, err := trinary.TrytesToTrits(txTrytes)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	tx, err := transaction.ParseTransaction(txTrits, true)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	hashTrits, err := curl.Hasher().Hash(txTrits)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	tx.Hash = trinary.MustTritsToTrytes(hashTrits)
 
 	if tx.Value != 0 {
 		// last trit must be zero because of KERL
 		if txTrits[consts.AddressTrinaryOffset+consts.AddressTrinarySize-1] != 0 {
-			return consts.ErrInvalidAddress
+			return nil, nil, consts.ErrInvalidAddress
 		}
 
 		if math.AbsInt64(tx.Value) > consts.TotalSupply {
-			return consts.ErrInsufficientBalance
+			return nil, nil, consts.ErrInsufficientBalance
 		}
 	}
 
 	if !transaction.HasValidNonce(tx, config.NodeConfig.GetUint64(config.CfgCoordinatorMWM)) {
-		return consts.ErrInvalidTransactionHash
+		return nil, nil, consts.ErrInvalidTransactionHash
 	}
 
-	return proc.CompressAndEmit(tx, txTrits)
+	return tx, txTrits, nil
 }
 
 // CompressAndEmit compresses the given transaction and emits TransactionProcessed and BroadcastTransaction events.
 // This function does not run within the Processor's worker pool.
 func (proc *Processor) CompressAndEmit(tx *transaction.Transaction, txTrits trinary.Trits) error {
+	hornetTx, err := proc.compress(tx, txTrits)
+	if err != nil {
+		return err
+	}
+
+	proc.Events.TransactionProcessed.Trigger(hornetTx, (*rqueue.Request)(nil), (*peer.Peer)(nil))
+	proc.Events.BroadcastTransaction.Trigger(&bqueue.Broadcast{
+		TxData:          hornetTx.RawBytes,
+		RequestedTxHash: hornetTx.GetTxHash(),
+	})
+	return nil
+}
+
+// compress compresses the given transaction and checks whether it may be accepted by the node.
+func (proc *Processor) compress(tx *transaction.Transaction, txTrits trinary.Trits) (*hornet.Transaction, error) {
 	txBytesTruncated := compressed.TruncateTxTrits(txTrits)
 	hornetTx := hornet.NewTransactionFromTx(tx, txBytesTruncated)
 
 	if timeValid, _ := proc.ValidateTimestamp(hornetTx); !timeValid {
-		return ErrInvalidTimestamp
+		return nil, ErrInvalidTimestamp
 	}
 
 	if _, isInvalidMilestoneTx := invalidMilestoneHashes[string(hornetTx.GetTxHash())]; isInvalidMilestoneTx {
 		// do not accept the invalid milestone transactions
-		return consts.ErrInvalidTransactionHash
+		return nil, consts.ErrInvalidTransactionHash
 	}
 
-	proc.Events.TransactionProcessed.Trigger(hornetTx, (*rqueue.Request)(nil), (*peer.Peer)(nil))
-	proc.Events.BroadcastTransaction.Trigger(&bqueue.Broadcast{
-		TxData:          txBytesTruncated,
-		RequestedTxHash: hornetTx.GetTxHash(),
-	})
-	return nil
+	return hornetTx, nil
 }
 
 // WorkUnitSize returns the size of WorkUnits currently cached.
@@ -205,6 +250,24 @@ func (proc *Processor) WorkUnitsSize() int {
 	return proc.workUnits.GetSize()
 }
 
+// ClearWorkUnits removes all cached WorkUnits, so that already seen transaction data is processed again.
+// Returns the amount of removed WorkUnits.
+func (proc *Processor) ClearWorkUnits() int {
+
+	var keysToDelete [][]byte
+
+	proc.workUnits.ForEachKeyOnly(func(key []byte) bool {
+		keysToDelete = append(keysToDelete, key)
+		return true
+	}, false)
+
+	for _, key := range keysToDelete {
+		proc.workUnits.Delete(key)
+	}
+
+	return len(keysToDelete)
+}
+
 // gets a CachedWorkUnit or creates a new one if it not existent.
 func (proc *Processor) workUnitFor(receivedTxBytes []byte) *CachedWorkUnit {
 	return &CachedWorkUnit{
@@ -219,6 +282,7 @@ func (proc *Processor) processMilestoneRequest(p *peer.Peer, data []byte) {
 	msIndex, err := sting.ExtractRequestedMilestoneIndex(data)
 	if err != nil {
 		metrics.SharedServerMetrics.InvalidRequests.Inc()
+		proc.pm.RecordPeerError(p, peering.PeerErrorInvalidRequest, err)
 
 		// drop the connection to the peer
 		proc.pm.Remove(p.ID)
@@ -286,6 +350,7 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 		wu.processingLock.Unlock()
 
 		metrics.SharedServerMetrics.InvalidTransactions.Inc()
+		proc.pm.RecordPeerError(p, peering.PeerErrorInvalidTransaction, ErrKnownInvalidTransaction)
 
 		// drop the connection to the peer
 		proc.pm.Remove(p.ID)
@@ -323,7 +388,7 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 	tx, err := compressed.TransactionFromCompressedBytes(wu.receivedTxBytes)
 	if err != nil {
 		wu.UpdateState(Invalid)
-		wu.punish()
+		wu.punish(err)
 		return
 	}
 
@@ -333,12 +398,7 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 	// mark the transaction as received
 	request := proc.requestQueue.Received(hornetTx.GetTxHash())
 
-	// validate minimum weight magnitude requirement
-	if request == nil && !transaction.HasValidNonce(tx, proc.opts.ValidMWM) {
-		wu.UpdateState(Invalid)
-		wu.punish()
-		return
-	}
+	validationErr := proc.validateTransaction(hornetTx, request != nil)
 
 	timestampValid, broadcast := proc.ValidateTimestamp(hornetTx)
 
@@ -347,10 +407,9 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 	wu.tx = hornetTx
 	wu.dataLock.Unlock()
 
-	if _, isInvalidMilestoneTx := invalidMilestoneHashes[string(wu.receivedTxHash)]; isInvalidMilestoneTx {
-		// do not accept the invalid milestone transactions
+	if validationErr != nil {
 		wu.UpdateState(Invalid)
-		wu.punish()
+		wu.punish(validationErr)
 		return
 	}
 
@@ -378,6 +437,44 @@ func (proc *Processor) processWorkUnit(wu *WorkUnit, p *peer.Peer) {
 	}
 }
 
+// validateTransaction checks the minimum weight magnitude requirement of unrequested transactions
+// and rejects the known invalid milestone transactions.
+func (proc *Processor) validateTransaction(hornetTx *hornet.Transaction, requested bool) error {
+	if !requested && !transaction.HasValidNonce(hornetTx.Tx, proc.opts.ValidMWM) {
+		return ErrInsufficientMWM
+	}
+
+	if _, isInvalidMilestoneTx := invalidMilestoneHashes[string(hornetTx.GetTxHash())]; isInvalidMilestoneTx {
+		// do not accept the invalid milestone transactions
+		return ErrInvalidMilestoneTransaction
+	}
+
+	return nil
+}
+
+// Reprocess runs the given received transaction data through the same checks as an unrequested transaction
+// received via gossip, without updating the cached WorkUnits, the request queue or the storage and without emitting events.
+// it returns the error the transaction would be dropped with, stale transactions are dropped with ErrInvalidTimestamp.
+// This function does not run within the Processor's worker pool.
+func (proc *Processor) Reprocess(receivedTxBytes []byte) error {
+	tx, err := compressed.TransactionFromCompressedBytes(receivedTxBytes)
+	if err != nil {
+		return err
+	}
+
+	hornetTx := hornet.NewTransactionFromTx(tx, receivedTxBytes)
+
+	if err := proc.validateTransaction(hornetTx, false); err != nil {
+		return err
+	}
+
+	if timestampValid, _ := proc.ValidateTimestamp(hornetTx); !timestampValid {
+		return ErrInvalidTimestamp
+	}
+
+	return nil
+}
+
 // checks whether the given transaction's timestamp is valid.
 // the timestamp is automatically valid if the transaction is a solid entry point.
 // the timestamp should be in the range of +/- 10 minutes to current time.
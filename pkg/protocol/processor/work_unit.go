@@ -6,6 +6,7 @@ import (
 
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/hornet"
+	peeringpkg "github.com/gohornet/hornet/pkg/peering"
 	"github.com/gohornet/hornet/pkg/peering/peer"
 	"github.com/gohornet/hornet/pkg/protocol/bqueue"
 	"github.com/gohornet/hornet/plugins/peering"
@@ -106,12 +107,13 @@ func (wu *WorkUnit) addReceivedFrom(p *peer.Peer, requestedTxHash hornet.Hash) {
 
 // punishes, respectively increases the invalid transaction metric of all peers
 // which sent the given underlying transaction of this WorkUnit.
-// it also closes the connection to these peers.
-func (wu *WorkUnit) punish() {
+// it also records the reason as an error of these peers and closes the connection to them.
+func (wu *WorkUnit) punish(reason error) {
 	wu.receivedFromLock.Lock()
 	defer wu.receivedFromLock.Unlock()
 	for _, p := range wu.receivedFrom {
 		metrics.SharedServerMetrics.InvalidTransactions.Inc()
+		peering.Manager().RecordPeerError(p, peeringpkg.PeerErrorInvalidTransaction, reason)
 
 		// drop the connection to the peer
 		peering.Manager().Remove(p.ID)
@@ -24,6 +24,7 @@ const (
 	PriorityAPI
 	PriorityMetricsPublishers
 	PrioritySpammer
+	PriorityReattacher
 	PriorityStatusReport
 	PriorityAutopeering
 	PriorityCoordinator
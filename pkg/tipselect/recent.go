@@ -0,0 +1,62 @@
+package tipselect
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+const (
+	// MaxRecentTipSelections defines the maximum amount of recently selected tip sets kept by the tip-selector.
+	MaxRecentTipSelections = 32
+)
+
+// TipSelection is a set of tips returned by the tip-selector.
+type TipSelection struct {
+	// Tips are the selected tips.
+	Tips hornet.Hashes
+	// Score is the score of the tip pool the tips were selected from.
+	Score Score
+	// Timestamp is the time the tips were selected.
+	Timestamp time.Time
+	// Duration is the duration of the whole tip-selection.
+	Duration time.Duration
+}
+
+// recentTipSelections are the most recently selected tip sets.
+// it is only used for debugging and doesn't influence the tip-selection.
+type recentTipSelections struct {
+	sync.RWMutex
+	selections *utils.RingBuffer
+}
+
+func newRecentTipSelections() *recentTipSelections {
+	return &recentTipSelections{selections: utils.NewRingBuffer(MaxRecentTipSelections)}
+}
+
+func (r *recentTipSelections) add(selection *TipSelection) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.selections.Add(selection)
+}
+
+// newestFirst returns the tip selections ordered from the newest to the oldest.
+func (r *recentTipSelections) newestFirst() []*TipSelection {
+	r.RLock()
+	defer r.RUnlock()
+
+	result := make([]*TipSelection, 0, r.selections.Len())
+	r.selections.ForEachNewestFirst(func(element interface{}) bool {
+		result = append(result, element.(*TipSelection))
+		return true
+	})
+	return result
+}
+
+// RecentTipSelections returns the most recently selected tip sets, ordered from the newest to the oldest.
+func (ts *TipSelector) RecentTipSelections() []*TipSelection {
+	return ts.recentSelections.newestFirst()
+}
@@ -35,6 +35,11 @@ func TipCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*Tip))(params[0].(*Tip))
 }
 
+// TipSelectionCaller is used to signal performed tip selections.
+func TipSelectionCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*TipSelection))(params[0].(*TipSelection))
+}
+
 // WalkerStatsCaller is used to signal tip selection events.
 func WalkerStatsCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*TipSelStats))(params[0].(*TipSelStats))
@@ -76,6 +81,8 @@ type Events struct {
 	TipRemoved *events.Event
 	// TipSelPerformed is fired when a tipselection was performed.
 	TipSelPerformed *events.Event
+	// TipsSelected is fired when a set of tips was returned by the tip-selector.
+	TipsSelected *events.Event
 }
 
 // TipSelector manages a list of tips and emits events for their removal and addition.
@@ -119,12 +126,17 @@ type TipSelector struct {
 	// spammerTipsThresholdSemiLazy is the maximum amount of tips in a tip-pool before the spammer tries to reduce these (0 = disable)
 	// this is used to support the network if someone attacks the tangle by spamming a lot of tips. (semi-lazy pool)
 	spammerTipsThresholdSemiLazy int
+	// allowUnsynced defines whether tips may be selected while the node is not synced.
+	// this is unsafe, because transactions attached during sync may be orphaned.
+	allowUnsynced bool
 	// nonLazyTipsMap contains only non-lazy tips.
 	nonLazyTipsMap map[string]*Tip
 	// semiLazyTipsMap contains only semi-lazy tips.
 	semiLazyTipsMap map[string]*Tip
 	// lock for the tipsMaps
 	tipsLock syncutils.Mutex
+	// recentSelections contains the most recently selected tip sets.
+	recentSelections *recentTipSelections
 	// Events are the events that are triggered by the TipSelector.
 	Events Events
 }
@@ -140,7 +152,8 @@ func New(maxDeltaTxYoungestRootSnapshotIndexToLSMI int,
 	retentionRulesTipsLimitSemiLazy int,
 	maxReferencedTipAgeSecondsSemiLazy time.Duration,
 	maxApproversSemiLazy uint32,
-	spammerTipsThresholdSemiLazy int) *TipSelector {
+	spammerTipsThresholdSemiLazy int,
+	allowUnsynced bool) *TipSelector {
 
 	return &TipSelector{
 		maxDeltaTxYoungestRootSnapshotIndexToLSMI: milestone.Index(maxDeltaTxYoungestRootSnapshotIndexToLSMI),
@@ -154,16 +167,24 @@ func New(maxDeltaTxYoungestRootSnapshotIndexToLSMI int,
 		maxReferencedTipAgeSecondsSemiLazy:        maxReferencedTipAgeSecondsSemiLazy,
 		maxApproversSemiLazy:                      maxApproversSemiLazy,
 		spammerTipsThresholdSemiLazy:              spammerTipsThresholdSemiLazy,
+		allowUnsynced:                             allowUnsynced,
 		nonLazyTipsMap:                            make(map[string]*Tip),
 		semiLazyTipsMap:                           make(map[string]*Tip),
+		recentSelections:                          newRecentTipSelections(),
 		Events: Events{
 			TipAdded:        events.NewEvent(TipCaller),
 			TipRemoved:      events.NewEvent(TipCaller),
 			TipSelPerformed: events.NewEvent(WalkerStatsCaller),
+			TipsSelected:    events.NewEvent(TipSelectionCaller),
 		},
 	}
 }
 
+// AllowUnsynced returns whether tips may be selected while the node is not synced.
+func (ts *TipSelector) AllowUnsynced() bool {
+	return ts.allowUnsynced
+}
+
 // AddTip adds the given tailTxHash as a tip.
 func (ts *TipSelector) AddTip(bndl *tangle.Bundle) {
 	ts.tipsLock.Lock()
@@ -259,6 +280,26 @@ func (ts *TipSelector) AddTip(bndl *tangle.Bundle) {
 	}
 }
 
+// ContainsTips returns whether all the given tail transaction hashes are currently part of the tip pools.
+func (ts *TipSelector) ContainsTips(tailTxHashes hornet.Hashes) bool {
+	ts.tipsLock.Lock()
+	defer ts.tipsLock.Unlock()
+
+	for _, tailTxHash := range tailTxHashes {
+		if _, exists := ts.nonLazyTipsMap[string(tailTxHash)]; exists {
+			continue
+		}
+
+		if _, exists := ts.semiLazyTipsMap[string(tailTxHash)]; exists {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
 // removeTipWithoutLocking removes the given tailTxHash from the tipsMap without acquiring the lock.
 func (ts *TipSelector) removeTipWithoutLocking(tipsMap map[string]*Tip, tailTxHash hornet.Hash) bool {
 	if tip, exists := tipsMap[string(tailTxHash)]; exists {
@@ -298,7 +339,7 @@ func (ts *TipSelector) randomTipWithoutLocking(tipsMap map[string]*Tip) (hornet.
 // selectTipWithoutLocking selects a tip.
 func (ts *TipSelector) selectTipWithoutLocking(tipsMap map[string]*Tip) (hornet.Hash, error) {
 
-	if !tangle.IsNodeSyncedWithThreshold() {
+	if !ts.allowUnsynced && !tangle.IsNodeSyncedWithThreshold() {
 		return nil, tangle.ErrNodeNotSynced
 	}
 
@@ -311,8 +352,30 @@ func (ts *TipSelector) selectTipWithoutLocking(tipsMap map[string]*Tip) (hornet.
 	return tipHash, err
 }
 
-// SelectTips selects two tips.
-func (ts *TipSelector) selectTips(tipsMap map[string]*Tip) (hornet.Hashes, error) {
+// selectTips selects two tips and records them in the recent tip selections.
+func (ts *TipSelector) selectTips(tipsMap map[string]*Tip, score Score) (hornet.Hashes, error) {
+	start := time.Now()
+
+	tips, err := ts.selectTipsWithoutRecording(tipsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.recordTipSelection(tips, score, time.Since(start))
+	return tips, nil
+}
+
+// recordTipSelection adds the selected tips to the recent tip selections, updates the metrics and fires the TipsSelected event.
+func (ts *TipSelector) recordTipSelection(tips hornet.Hashes, score Score, duration time.Duration) {
+	selection := &TipSelection{Tips: tips, Score: score, Timestamp: time.Now(), Duration: duration}
+
+	ts.recentSelections.add(selection)
+	metrics.SharedServerMetrics.TipSelections.Add(1)
+	ts.Events.TipsSelected.Trigger(selection)
+}
+
+// selectTipsWithoutRecording selects two tips.
+func (ts *TipSelector) selectTipsWithoutRecording(tipsMap map[string]*Tip) (hornet.Hashes, error) {
 	tips := hornet.Hashes{}
 
 	ts.tipsLock.Lock()
@@ -348,12 +411,68 @@ func (ts *TipSelector) selectTips(tipsMap map[string]*Tip) (hornet.Hashes, error
 
 // SelectSemiLazyTips selects two semi-lazy tips.
 func (ts *TipSelector) SelectSemiLazyTips() (hornet.Hashes, error) {
-	return ts.selectTips(ts.semiLazyTipsMap)
+	return ts.selectTips(ts.semiLazyTipsMap, ScoreSemiLazy)
 }
 
 // SelectNonLazyTips selects two non-lazy tips.
 func (ts *TipSelector) SelectNonLazyTips() (hornet.Hashes, error) {
-	return ts.selectTips(ts.nonLazyTipsMap)
+	return ts.selectTips(ts.nonLazyTipsMap, ScoreNonLazy)
+}
+
+// SelectNonLazyTipsCount selects up to count distinct non-lazy tips.
+// if the pool contains less than count non-lazy tips, all of them are returned and exhausted is set.
+func (ts *TipSelector) SelectNonLazyTipsCount(count int) (tips hornet.Hashes, exhausted bool, err error) {
+	// record stats
+	start := time.Now()
+
+	tips, exhausted, err = ts.selectNonLazyTipsCountWithoutRecording(count)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the events are fired after the lock was released, so the handlers can't block the tip selection
+	duration := time.Since(start)
+	ts.Events.TipSelPerformed.Trigger(&TipSelStats{Duration: duration})
+	ts.recordTipSelection(tips, ScoreNonLazy, duration)
+
+	return tips, exhausted, nil
+}
+
+// selectNonLazyTipsCountWithoutRecording selects up to count distinct non-lazy tips.
+func (ts *TipSelector) selectNonLazyTipsCountWithoutRecording(count int) (tips hornet.Hashes, exhausted bool, err error) {
+	ts.tipsLock.Lock()
+	defer ts.tipsLock.Unlock()
+
+	if !ts.allowUnsynced && !tangle.IsNodeSyncedWithThreshold() {
+		return nil, false, tangle.ErrNodeNotSynced
+	}
+
+	if len(ts.nonLazyTipsMap) == 0 {
+		return nil, false, ErrNoTipsAvailable
+	}
+
+	if len(ts.nonLazyTipsMap) <= count {
+		for _, tip := range ts.nonLazyTipsMap {
+			tips = append(tips, tip.Hash)
+		}
+		exhausted = len(tips) < count
+	} else {
+		selected := make(map[string]struct{}, count)
+		for len(tips) < count {
+			tipHash, err := ts.randomTipWithoutLocking(ts.nonLazyTipsMap)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if _, exists := selected[string(tipHash)]; exists {
+				continue
+			}
+			selected[string(tipHash)] = struct{}{}
+			tips = append(tips, tipHash)
+		}
+	}
+
+	return tips, exhausted, nil
 }
 
 func (ts *TipSelector) SelectSpammerTips() (isSemiLazy bool, tips hornet.Hashes, err error) {
@@ -484,6 +603,17 @@ func (ts *TipSelector) UpdateScores() int {
 	return count
 }
 
+// CalculateScore calculates the current score of the given transaction the same way as for the tips in the pool.
+func (ts *TipSelector) CalculateScore(txHash hornet.Hash) Score {
+	return ts.calculateScore(txHash, tangle.GetSolidMilestoneIndex())
+}
+
+// CalculateScoreForIndex calculates the tip selection score of this transaction relative to the given LSMI,
+// so that the score matches other values which were computed for the same LSMI.
+func (ts *TipSelector) CalculateScoreForIndex(txHash hornet.Hash, lsmi milestone.Index) Score {
+	return ts.calculateScore(txHash, lsmi)
+}
+
 // calculateScore calculates the tip selection score of this transaction
 func (ts *TipSelector) calculateScore(txHash hornet.Hash, lsmi milestone.Index) Score {
 	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
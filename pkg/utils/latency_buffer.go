@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatencyBuffer is a fixed-size rolling buffer of latency samples.
+// If the buffer is full, the oldest sample gets overwritten.
+type LatencyBuffer struct {
+	lock    sync.RWMutex
+	samples *RingBuffer
+}
+
+// NewLatencyBuffer creates a new LatencyBuffer that holds up to size samples.
+func NewLatencyBuffer(size int) *LatencyBuffer {
+	return &LatencyBuffer{samples: NewRingBuffer(size)}
+}
+
+// Add a new sample to the buffer.
+func (b *LatencyBuffer) Add(latency time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.samples.Add(latency)
+}
+
+// Count returns the amount of samples in the buffer.
+func (b *LatencyBuffer) Count() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.samples.Len()
+}
+
+// Percentiles returns the given percentiles (0-100) of the samples in the buffer
+// (nearest-rank method) and the amount of samples they were calculated from.
+func (b *LatencyBuffer) Percentiles(percentiles ...float64) ([]time.Duration, int) {
+	b.lock.RLock()
+	count := b.samples.Len()
+	sorted := make([]time.Duration, 0, count)
+	b.samples.ForEachOldestFirst(func(element interface{}) bool {
+		sorted = append(sorted, element.(time.Duration))
+		return true
+	})
+	b.lock.RUnlock()
+
+	result := make([]time.Duration, len(percentiles))
+	if count == 0 {
+		return result, 0
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	for i, p := range percentiles {
+		rank := int(p/100*float64(count)+0.5) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		if rank >= count {
+			rank = count - 1
+		}
+		result[i] = sorted[rank]
+	}
+
+	return result, count
+}
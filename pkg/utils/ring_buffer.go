@@ -0,0 +1,63 @@
+package utils
+
+// RingBuffer is a fixed-size buffer of elements.
+// If the buffer is full, the oldest element gets overwritten.
+// It is not safe for concurrent use, the callers have to guard it with their own lock.
+type RingBuffer struct {
+	elements []interface{}
+	next     int
+	count    int
+}
+
+// NewRingBuffer creates a new RingBuffer that holds up to size elements.
+func NewRingBuffer(size int) *RingBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &RingBuffer{elements: make([]interface{}, size)}
+}
+
+// Add adds a new element to the buffer and returns the overwritten oldest element,
+// or nil if the buffer was not full.
+func (r *RingBuffer) Add(element interface{}) (evicted interface{}) {
+	if r.count == len(r.elements) {
+		evicted = r.elements[r.next]
+	} else {
+		r.count++
+	}
+
+	r.elements[r.next] = element
+	r.next = (r.next + 1) % len(r.elements)
+
+	return evicted
+}
+
+// Len returns the amount of elements in the buffer.
+func (r *RingBuffer) Len() int {
+	return r.count
+}
+
+// Cap returns the maximum amount of elements in the buffer.
+func (r *RingBuffer) Cap() int {
+	return len(r.elements)
+}
+
+// ForEachNewestFirst calls the consumer for the elements ordered from the newest to the oldest,
+// until the consumer returns false.
+func (r *RingBuffer) ForEachNewestFirst(consumer func(element interface{}) bool) {
+	for i := 1; i <= r.count; i++ {
+		if !consumer(r.elements[(r.next-i+len(r.elements))%len(r.elements)]) {
+			return
+		}
+	}
+}
+
+// ForEachOldestFirst calls the consumer for the elements ordered from the oldest to the newest,
+// until the consumer returns false.
+func (r *RingBuffer) ForEachOldestFirst(consumer func(element interface{}) bool) {
+	for i := r.count; i > 0; i-- {
+		if !consumer(r.elements[(r.next-i+len(r.elements))%len(r.elements)]) {
+			return
+		}
+	}
+}
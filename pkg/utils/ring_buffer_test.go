@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+func ringBufferElements(r *utils.RingBuffer, newestFirst bool) []interface{} {
+	var elements []interface{}
+	consumer := func(element interface{}) bool {
+		elements = append(elements, element)
+		return true
+	}
+
+	if newestFirst {
+		r.ForEachNewestFirst(consumer)
+	} else {
+		r.ForEachOldestFirst(consumer)
+	}
+	return elements
+}
+
+func TestRingBuffer(t *testing.T) {
+	r := utils.NewRingBuffer(3)
+	assert.Equal(t, 0, r.Len())
+	assert.Equal(t, 3, r.Cap())
+	assert.Empty(t, ringBufferElements(r, true))
+
+	assert.Nil(t, r.Add(1))
+	assert.Nil(t, r.Add(2))
+	assert.Equal(t, 2, r.Len())
+	assert.Equal(t, []interface{}{1, 2}, ringBufferElements(r, false))
+	assert.Equal(t, []interface{}{2, 1}, ringBufferElements(r, true))
+
+	assert.Nil(t, r.Add(3))
+	assert.Equal(t, 3, r.Len())
+
+	// the oldest element is overwritten if the buffer is full
+	assert.Equal(t, 1, r.Add(4))
+	assert.Equal(t, 2, r.Add(5))
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, []interface{}{3, 4, 5}, ringBufferElements(r, false))
+	assert.Equal(t, []interface{}{5, 4, 3}, ringBufferElements(r, true))
+}
+
+func TestRingBufferStopIteration(t *testing.T) {
+	r := utils.NewRingBuffer(5)
+	for i := 1; i <= 7; i++ {
+		r.Add(i)
+	}
+
+	var elements []interface{}
+	r.ForEachNewestFirst(func(element interface{}) bool {
+		elements = append(elements, element)
+		return len(elements) < 2
+	})
+	assert.Equal(t, []interface{}{7, 6}, elements)
+}
+
+func TestRingBufferMinimumSize(t *testing.T) {
+	r := utils.NewRingBuffer(0)
+	assert.Equal(t, 1, r.Cap())
+
+	assert.Nil(t, r.Add("a"))
+	assert.Equal(t, "a", r.Add("b"))
+	assert.Equal(t, []interface{}{"b"}, ringBufferElements(r, true))
+}
@@ -132,11 +132,18 @@ func ConfirmMilestone(cachedTxMetas map[string]*tangle.CachedMetadata, cachedMsB
 
 	confirmationTime := cachedMsTailTx.GetTransaction().GetTimestamp()
 
+	// the position of each referenced tail in the white flag order is persisted in the metadata of its bundle txs
+	whiteFlagIndexes := make(map[string]uint32, len(mutations.TailsReferenced))
+	for i, txHash := range mutations.TailsReferenced {
+		whiteFlagIndexes[string(txHash)] = uint32(i)
+	}
+
 	// confirm all txs of the included tails
 	for _, txHash := range mutations.TailsIncluded {
 		if err := forEachBundleTxMetaWithTailTxHash(txHash, func(txMeta *tangle.CachedMetadata) {
 			if !txMeta.GetMetadata().IsConfirmed() {
 				txMeta.GetMetadata().SetConfirmed(true, milestoneIndex)
+				txMeta.GetMetadata().SetWhiteFlagIndex(whiteFlagIndexes[string(txHash)])
 				txMeta.GetMetadata().SetRootSnapshotIndexes(milestoneIndex, milestoneIndex, milestoneIndex)
 				conf.TxsConfirmed++
 				conf.TxsValue++
@@ -154,6 +161,7 @@ func ConfirmMilestone(cachedTxMetas map[string]*tangle.CachedMetadata, cachedMsB
 		if err := forEachBundleTxMetaWithTailTxHash(txHash, func(txMeta *tangle.CachedMetadata) {
 			if !txMeta.GetMetadata().IsConfirmed() {
 				txMeta.GetMetadata().SetConfirmed(true, milestoneIndex)
+				txMeta.GetMetadata().SetWhiteFlagIndex(whiteFlagIndexes[string(txHash)])
 				txMeta.GetMetadata().SetRootSnapshotIndexes(milestoneIndex, milestoneIndex, milestoneIndex)
 				conf.TxsConfirmed++
 				conf.TxsZeroValue++
@@ -172,6 +180,7 @@ func ConfirmMilestone(cachedTxMetas map[string]*tangle.CachedMetadata, cachedMsB
 			txMeta.GetMetadata().SetConflicting(true)
 			if !txMeta.GetMetadata().IsConfirmed() {
 				txMeta.GetMetadata().SetConfirmed(true, milestoneIndex)
+				txMeta.GetMetadata().SetWhiteFlagIndex(whiteFlagIndexes[string(txHash)])
 				txMeta.GetMetadata().SetRootSnapshotIndexes(milestoneIndex, milestoneIndex, milestoneIndex)
 				conf.TxsConfirmed++
 				conf.TxsConflicting++
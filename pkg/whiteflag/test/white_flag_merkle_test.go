@@ -16,7 +16,7 @@ import (
 	"github.com/gohornet/hornet/pkg/whiteflag"
 )
 
-func TestWhiteFlagMerkleTreeHash(t *testing.T) {
+func whiteFlagMerkleTestTailHashes() []hornet.Hash {
 
 	// Test vectors taken from the example in the RFC-0012: https://github.com/Wollac/iota-crypto-demo/tree/master/examples/merkle
 
@@ -30,9 +30,33 @@ func TestWhiteFlagMerkleTreeHash(t *testing.T) {
 	tailHashes = append(tailHashes, t5b1.EncodeTrytes("Q9GHMAITEZCWKFIESJARYQYMF9XWFPQTTFRXULLHQDWEZLYBSFYHSLPXEHBORDDFYZRFYFGDCM9VJKEFR"))
 	tailHashes = append(tailHashes, t5b1.EncodeTrytes("GMNECTSPSLSPPEITCHBXSN9KZD9OZPVPOET9TVQJDZMFGN9SGPRPMUQARNXUVKMWAFAKLKWBZLWZCTPCP"))
 
+	return tailHashes
+}
+
+func TestWhiteFlagMerkleTreeHash(t *testing.T) {
+
+	tailHashes := whiteFlagMerkleTestTailHashes()
+
 	hash := whiteflag.NewHasher(crypto.BLAKE2b_512).TreeHash(tailHashes)
 
 	expectedHash, err := hex.DecodeString("d07161bdb535afb7dbb3f5b2fb198ecf715cbd9dfca133d2b48d67b1e11173c6f92bed2f4dca92c36e8d1ef279a0c19ca9e40a113e9f5526090342988f86e53a")
 	require.NoError(t, err)
 	require.True(t, bytes.Equal(hash, expectedHash))
 }
+
+func TestWhiteFlagMerkleAuditPath(t *testing.T) {
+
+	tailHashes := whiteFlagMerkleTestTailHashes()
+
+	hasher := whiteflag.NewHasher(crypto.BLAKE2b_512)
+	root := hasher.TreeHash(tailHashes)
+
+	for index, tailHash := range tailHashes {
+		auditPath := hasher.AuditPath(tailHashes, index)
+		require.True(t, hasher.VerifyAuditPath(tailHash, index, len(tailHashes), auditPath, root))
+		require.False(t, hasher.VerifyAuditPath(tailHash, (index+1)%len(tailHashes), len(tailHashes), auditPath, root))
+	}
+
+	// a single leaf is its own root
+	require.True(t, hasher.VerifyAuditPath(tailHashes[0], 0, 1, hasher.AuditPath(tailHashes[:1], 0), hasher.TreeHash(tailHashes[:1])))
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/pkg/testsuite"
 	"github.com/gohornet/hornet/pkg/testsuite/utils"
 )
@@ -20,6 +22,14 @@ const (
 	showConfirmationGraphs = false
 )
 
+func whiteFlagIndex(t *testing.T, txHash hornet.Hash) (uint32, bool) {
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
+	require.NotNil(t, cachedTxMeta)
+	defer cachedTxMeta.Release(true) // meta -1
+
+	return cachedTxMeta.GetMetadata().GetWhiteFlagIndex()
+}
+
 func TestWhiteFlagWithMultipleConflicting(t *testing.T) {
 
 	// Fill up the balances
@@ -100,6 +110,19 @@ func TestWhiteFlagWithOnlyZeroTx(t *testing.T) {
 	require.Equal(t, 0, conf.TxsValue)
 	require.Equal(t, 0, conf.TxsConflicting)
 
+	// The white flag order is a post-order traversal, so approvees come before their approvers
+	indexA, knownA := whiteFlagIndex(t, bundleA.GetBundle().GetTailHash())
+	indexB, knownB := whiteFlagIndex(t, bundleB.GetBundle().GetTailHash())
+	indexE, knownE := whiteFlagIndex(t, bundleE.GetBundle().GetTailHash())
+	require.True(t, knownA)
+	require.True(t, knownB)
+	require.True(t, knownE)
+	require.Less(t, indexA, indexB)
+	require.Less(t, indexB, indexE)
+
+	_, knownC := whiteFlagIndex(t, bundleC.GetBundle().GetTailHash())
+	require.False(t, knownC)
+
 	// Issue another bundle
 	bundleF := te.AttachAndStoreBundle(bundleD.GetBundle().GetTailHash(), bundleE.GetBundle().GetTailHash(), utils.ZeroValueTx(t, "F"))
 
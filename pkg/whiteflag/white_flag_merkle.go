@@ -1,6 +1,7 @@
 package whiteflag
 
 import (
+	"bytes"
 	"crypto"
 	"math/bits"
 
@@ -41,6 +42,49 @@ func (t *Hasher) TreeHash(tailHashes []hornet.Hash) []byte {
 	return t.HashNode(t.TreeHash(tailHashes[:k]), t.TreeHash(tailHashes[k:]))
 }
 
+// AuditPath computes the Merkle audit path of the hash at the given index in the provided hashes.
+// The path is ordered from the leaf to the root.
+func (t *Hasher) AuditPath(tailHashes []hornet.Hash, index int) [][]byte {
+	if len(tailHashes) < 2 {
+		return [][]byte{}
+	}
+
+	k := largestPowerOfTwo(len(tailHashes))
+	if index < k {
+		return append(t.AuditPath(tailHashes[:k], index), t.TreeHash(tailHashes[k:]))
+	}
+	return append(t.AuditPath(tailHashes[k:], index-k), t.TreeHash(tailHashes[:k]))
+}
+
+// VerifyAuditPath checks whether the audit path proves the inclusion of the hash at the given index
+// in a Merkle tree with the given amount of leaves and root hash.
+func (t *Hasher) VerifyAuditPath(hash hornet.Hash, index int, size int, auditPath [][]byte, root []byte) bool {
+	if index < 0 || index >= size {
+		return false
+	}
+
+	fn, sn := index, size-1
+	r := t.HashLeaf(hash)
+	for _, p := range auditPath {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			r = t.HashNode(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = t.HashNode(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	return sn == 0 && bytes.Equal(r, root)
+}
+
 // HashLeaf returns the Merkle tree leaf hash of the input hash.
 func (t *Hasher) HashLeaf(hash hornet.Hash) []byte {
 	h := t.New()
@@ -3,7 +3,6 @@ package autopeering
 import (
 	"errors"
 	"fmt"
-	"hash/fnv"
 	"net"
 	"strings"
 
@@ -37,6 +36,8 @@ var (
 
 	// ID is the node's autopeering ID
 	ID string
+	// PublicKey is the node's autopeering public key, which is used by other nodes to add this node as an entry node
+	PublicKey string
 
 	// ErrParsingEntryNode is returned when parsing the entry node config entry failed.
 	ErrParsingEntryNode = errors.New("can't parse entry node")
@@ -48,11 +49,7 @@ func configureAutopeering(local *Local) {
 		log.Warn(err)
 	}
 
-	gossipServiceKeyHash := fnv.New32a()
-	gossipServiceKeyHash.Write([]byte(services.GossipServiceKey()))
-	networkID := gossipServiceKeyHash.Sum32()
-
-	discoveryProtocol = discover.New(local.PeerLocal, protocolVersion, networkID, discover.Logger(log.Named("disc")), discover.MasterPeers(entryNodes))
+	discoveryProtocol = discover.New(local.PeerLocal, protocolVersion, services.NetworkID(), discover.Logger(log.Named("disc")), discover.MasterPeers(entryNodes))
 
 	// only enable peer selection when the peering plugin is enabled
 	if !node.IsSkipped(peering.PLUGIN) {
@@ -109,6 +106,7 @@ func start(local *Local, shutdownSignal <-chan struct{}) {
 	}
 
 	ID = lPeer.ID().String()
+	PublicKey = lPeer.PublicKey().String()
 	log.Infof("started: ID=%s Address=%s/%s PublicKey=%s", lPeer.ID(), localAddr.String(), localAddr.Network(), lPeer.PublicKey().String())
 
 	<-shutdownSignal
@@ -0,0 +1,123 @@
+package gossip
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/protocol/helpers"
+	"github.com/gohornet/hornet/pkg/protocol/sting"
+)
+
+const (
+	// PingCacheInterval is the interval in which the last measured round-trip time of a peer is reused
+	// instead of sending a new ping, to avoid ping floods.
+	PingCacheInterval = 10 * time.Second
+)
+
+var (
+	// ErrPingNotSupported is returned if the peer does not support the STING protocol.
+	ErrPingNotSupported = errors.New("peer does not support pings")
+	// ErrPingNoData is returned if the peer has no milestone data which could be requested for a ping.
+	ErrPingNoData = errors.New("peer has no milestone data which can be requested")
+	// ErrPingInProgress is returned if there is already a ping in progress for the peer.
+	ErrPingInProgress = errors.New("ping already in progress")
+	// ErrPingTimeout is returned if the peer did not answer the ping in time.
+	ErrPingTimeout = errors.New("ping timed out")
+)
+
+// Ping measures the round-trip time to the given peer.
+// There is no dedicated ping message in the STING protocol, so the tail transaction of a milestone which the peer
+// has according to its last heartbeat is requested and the time until the peer sends it back is measured.
+// If the peer was pinged within the PingCacheInterval, the last measured round-trip time is returned.
+func Ping(p *peer.Peer, timeout time.Duration) (rtt time.Duration, cached bool, err error) {
+	if !p.Protocol.Supports(sting.FeatureSet) {
+		return 0, false, ErrPingNotSupported
+	}
+
+	if latestRTT, latestPingTime := p.LatestPing(); !latestPingTime.IsZero() && time.Since(latestPingTime) < PingCacheInterval {
+		return latestRTT, true, nil
+	}
+
+	msIndex := tangle.GetSolidMilestoneIndex()
+	if p.LatestHeartbeat != nil && p.LatestHeartbeat.SolidMilestoneIndex < msIndex {
+		msIndex = p.LatestHeartbeat.SolidMilestoneIndex
+	}
+
+	txHash, txBytes := milestoneTailForPing(p, msIndex)
+	if txHash == nil {
+		return 0, false, ErrPingNoData
+	}
+
+	ping := &peer.PendingPing{
+		ExpectedTxBytes: txBytes,
+		SentTime:        time.Now(),
+		ReplyChan:       make(chan time.Duration, 1),
+	}
+
+	if !p.SetPendingPing(ping) {
+		return 0, false, ErrPingInProgress
+	}
+
+	helpers.SendTransactionRequest(p, txHash)
+
+	select {
+	case rtt = <-ping.ReplyChan:
+	case <-time.After(timeout):
+		err = ErrPingTimeout
+	}
+
+	p.ClearPendingPing(ping)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	p.SetLatestPing(rtt)
+
+	return rtt, false, nil
+}
+
+// milestoneTailForPing returns the hash and the raw bytes of the tail transaction of the given milestone,
+// if the peer has the data for it.
+func milestoneTailForPing(p *peer.Peer, msIndex milestone.Index) (hornet.Hash, []byte) {
+	if !p.HasDataFor(msIndex) {
+		return nil, nil
+	}
+
+	cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+	if cachedMs == nil {
+		return nil, nil
+	}
+	defer cachedMs.Release(true) // bundle -1
+
+	cachedTailTx := cachedMs.GetBundle().GetTail() // tx +1
+	if cachedTailTx == nil {
+		return nil, nil
+	}
+	defer cachedTailTx.Release(true) // tx -1
+
+	return cachedTailTx.GetTransaction().GetTxHash(), cachedTailTx.GetTransaction().RawBytes
+}
+
+// checkPingReply checks whether the received transaction data is the reply to a pending ping of the peer.
+func checkPingReply(p *peer.Peer, data []byte) {
+	ping := p.PendingPing()
+	if ping == nil || !bytes.Equal(ping.ExpectedTxBytes, data) {
+		return
+	}
+
+	if !p.ClearPendingPing(ping) {
+		// the ping timed out or was answered in the meantime
+		return
+	}
+
+	select {
+	case ping.ReplyChan <- time.Since(ping.SentTime):
+	default:
+	}
+}
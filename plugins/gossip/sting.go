@@ -17,7 +17,9 @@ func addSTINGMessageEventHandlers(p *peer.Peer) {
 
 	p.Protocol.Events.Received[sting.MessageTypeTransaction].Attach(events.NewClosure(func(data []byte) {
 		p.Metrics.ReceivedTransactions.Inc()
+		p.RecordActivity()
 		metrics.SharedServerMetrics.Transactions.Inc()
+		checkPingReply(p, data)
 		msgProcessor.Process(p, sting.MessageTypeTransaction, data)
 	}))
 
@@ -29,6 +31,7 @@ func addSTINGMessageEventHandlers(p *peer.Peer) {
 
 	p.Protocol.Events.Received[sting.MessageTypeTransactionRequest].Attach(events.NewClosure(func(data []byte) {
 		p.Metrics.ReceivedTransactionRequests.Inc()
+		p.RecordActivity()
 		metrics.SharedServerMetrics.ReceivedTransactionRequests.Inc()
 		msgProcessor.Process(p, sting.MessageTypeTransactionRequest, data)
 	}))
@@ -41,6 +44,7 @@ func addSTINGMessageEventHandlers(p *peer.Peer) {
 
 	p.Protocol.Events.Received[sting.MessageTypeMilestoneRequest].Attach(events.NewClosure(func(data []byte) {
 		p.Metrics.ReceivedMilestoneRequests.Inc()
+		p.RecordActivity()
 		metrics.SharedServerMetrics.ReceivedMilestoneRequests.Inc()
 		msgProcessor.Process(p, sting.MessageTypeMilestoneRequest, data)
 	}))
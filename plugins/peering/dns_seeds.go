@@ -0,0 +1,78 @@
+package peering
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/peering"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+// lookupDNSSeed resolves the TXT records of the given DNS name.
+// every record may contain several peer addresses separated by commas or whitespaces.
+func lookupDNSSeed(name string) ([]string, error) {
+	records, err := net.LookupTXT(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []string
+	for _, record := range records {
+		addrs = append(addrs, strings.FieldsFunc(record, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })...)
+	}
+	return addrs, nil
+}
+
+// seedPeersFromDNS adds all peers found in the TXT records of the configured DNS seeds.
+// resolution failures are logged and do not stop the seeding of the other names.
+func seedPeersFromDNS(names []string) {
+	preferIPv6 := config.NodeConfig.GetBool(config.CfgNetPreferIPv6)
+
+	for _, name := range names {
+		addrs, err := lookupDNSSeed(name)
+		if err != nil {
+			log.Warnf("couldn't resolve DNS seed %s: %s", name, err)
+			continue
+		}
+
+		added := 0
+		for _, addr := range addrs {
+			if err := manager.AddDNSSeeded(addr, preferIPv6); err != nil {
+				if !errors.Is(err, peering.ErrPeerAlreadyConnected) && !errors.Is(err, peering.ErrPeerAlreadyInReconnect) {
+					log.Warnf("couldn't add peer %s from DNS seed %s: %s", addr, name, err)
+				}
+				continue
+			}
+			added++
+		}
+		log.Infof("added %d/%d peers from DNS seed %s", added, len(addrs), name)
+	}
+}
+
+func runDNSSeeding() {
+	names := config.NodeConfig.GetStringSlice(config.CfgNetDNSSeeds)
+	if len(names) == 0 {
+		return
+	}
+
+	refreshInterval := time.Duration(config.NodeConfig.GetInt(config.CfgNetDNSSeedsRefreshIntervalMinutes)) * time.Minute
+
+	daemon.BackgroundWorker("Peering DNS Seeds", func(shutdownSignal <-chan struct{}) {
+		// the first seeding is done in the background worker, so the startup is not blocked by the DNS resolution
+		seedPeersFromDNS(names)
+
+		if refreshInterval == 0 {
+			return
+		}
+
+		timeutil.Ticker(func() {
+			seedPeersFromDNS(names)
+		}, refreshInterval, shutdownSignal)
+	}, shutdown.PriorityPeerReconnecter)
+}
@@ -72,6 +72,11 @@ func Manager() *peering.Manager {
 			},
 			MaxConnected:  config.PeeringConfig.GetInt(config.CfgPeeringMaxPeers),
 			AcceptAnyPeer: config.PeeringConfig.GetBool(config.CfgPeeringAcceptAnyConnection),
+			ScoreWeights: peering.ScoreWeights{
+				Data:    config.NodeConfig.GetFloat64(config.CfgNetPeerScoringWeightsData),
+				Latency: config.NodeConfig.GetFloat64(config.CfgNetPeerScoringWeightsLatency),
+				Uptime:  config.NodeConfig.GetFloat64(config.CfgNetPeerScoringWeightsUptime),
+			},
 		}, peers...)
 	})
 	return manager
@@ -182,6 +187,21 @@ func run(_ *node.Plugin) {
 		}
 	}, shutdown.PriorityPeerReconnecter)
 
+	runDNSSeeding()
+
+	if config.NodeConfig.GetBool(config.CfgNetGossipAutoDisconnectIdleEnabled) {
+		maxIdle := time.Duration(config.NodeConfig.GetInt(config.CfgNetGossipAutoDisconnectIdleTimeoutSeconds)) * time.Second
+
+		// create a background worker that disconnects idle peers to free the slots for other peers
+		daemon.BackgroundWorker("Peering IdleCheck", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(func() {
+				for _, p := range Manager().DisconnectIdlePeers(maxIdle) {
+					log.Infof("disconnected neighbor %s because it didn't send any transactions or requests for %v", p.ID, maxIdle)
+				}
+			}, 10*time.Second, shutdownSignal)
+		}, shutdown.PriorityPeerReconnecter)
+	}
+
 	if config.NodeConfig.GetInt(config.CfgNetAutopeeringMaxDroppedPacketsPercentage) != 0 {
 		// create a background worker that checks for staled autopeers every minute
 		daemon.BackgroundWorker("Peering StaleCheck", func(shutdownSignal <-chan struct{}) {
@@ -212,4 +232,19 @@ func run(_ *node.Plugin) {
 			timeutil.Ticker(checkStaledPeers, 60*time.Second, shutdownSignal)
 		}, shutdown.PriorityPeerReconnecter)
 	}
+
+	if config.NodeConfig.GetBool(config.CfgNetPeerScoringPruningEnabled) {
+		maxAutopeers := config.NodeConfig.GetInt(config.CfgNetAutopeeringInboundPeers) + config.NodeConfig.GetInt(config.CfgNetAutopeeringOutboundPeers)
+		interval := time.Duration(config.NodeConfig.GetInt(config.CfgNetPeerScoringPruningIntervalSeconds)) * time.Second
+		gracePeriod := time.Duration(config.NodeConfig.GetInt(config.CfgNetPeerScoringPruningGracePeriodSeconds)) * time.Second
+
+		// create a background worker that disconnects the lowest scored autopeer to free the slot for potentially better peers
+		daemon.BackgroundWorker("Peering ScorePruning", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(func() {
+				if p, score := Manager().PruneLowestScoredAutopeer(maxAutopeers, gracePeriod); p != nil {
+					log.Infof("dropping autopeered neighbor %s / %s because it has the lowest score (%0.2f)", p.Autopeering.Address(), p.Autopeering.ID(), score)
+				}
+			}, interval, shutdownSignal)
+		}, shutdown.PriorityPeerReconnecter)
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/plugins/cli"
 	"github.com/gohornet/hornet/plugins/gossip"
+	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
 	"github.com/iotaledger/iota.go/consts"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -20,6 +21,8 @@ var (
 	infoPruningIndex          prometheus.Gauge
 	infoTips                  prometheus.Gauge
 	infoTransactionsToRequest prometheus.Gauge
+	infoTransactionsToProcess prometheus.Gauge
+	infoMilestonesToSolidify  prometheus.Gauge
 )
 
 func init() {
@@ -68,6 +71,14 @@ func init() {
 		Name: "iota_info_transactions_to_request",
 		Help: "Number of transactions to request.",
 	})
+	infoTransactionsToProcess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_info_transactions_to_process",
+		Help: "Number of received transactions which wait for being processed.",
+	})
+	infoMilestonesToSolidify = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_info_milestones_to_solidify",
+		Help: "Number of known milestones which are not solid yet.",
+	})
 
 	infoApp.WithLabelValues(cli.AppName, cli.AppVersion).Set(1)
 
@@ -80,6 +91,8 @@ func init() {
 	registry.MustRegister(infoPruningIndex)
 	registry.MustRegister(infoTips)
 	registry.MustRegister(infoTransactionsToRequest)
+	registry.MustRegister(infoTransactionsToProcess)
+	registry.MustRegister(infoMilestonesToSolidify)
 
 	addCollect(collectInfo)
 }
@@ -130,4 +143,8 @@ func collectInfo() {
 	// Transactions to request
 	queued, pending, _ := gossip.RequestQueue().Size()
 	infoTransactionsToRequest.Set(float64(queued + pending))
+
+	// Solidification backlog
+	infoTransactionsToProcess.Set(float64(tangleplugin.GetReceiveTxQueueSize()))
+	infoMilestonesToSolidify.Set(float64(tangleplugin.GetMilestonesToSolidifyCount()))
 }
@@ -38,6 +38,9 @@ func configure(plugin *node.Plugin) {
 	if config.NodeConfig.GetBool(config.CfgPrometheusProcessMetrics) {
 		registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	}
+	if config.NodeConfig.GetBool(config.CfgPrometheusTipSelectionMetrics) {
+		configureTipSelection()
+	}
 }
 
 func addCollect(collect func()) {
@@ -76,6 +79,8 @@ func run(plugin *node.Plugin) {
 		writeFileServiceDiscoveryFile()
 	}
 
+	runTipSelection()
+
 	daemon.BackgroundWorker("Prometheus exporter", func(shutdownSignal <-chan struct{}) {
 		log.Info("Starting Prometheus exporter ... done")
 
@@ -2,6 +2,7 @@ package prometheus
 
 import (
 	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/plugins/webapi"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -24,6 +25,9 @@ var (
 	serverSentSpamTransactions        prometheus.Gauge
 	serverValidatedBundles            prometheus.Gauge
 	serverSeenSpentAddresses          prometheus.Gauge
+	serverRejectedTagQuotaTxs         prometheus.Gauge
+	serverStreamBroadcastQueueDepth   prometheus.Gauge
+	serverStreamSubscribers           *prometheus.GaugeVec
 )
 
 func init() {
@@ -99,6 +103,21 @@ func init() {
 		Name: "iota_server_seen_spent_addresses",
 		Help: "Number of seen spent addresses.",
 	})
+	serverRejectedTagQuotaTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_server_rejected_tag_quota_transactions",
+		Help: "Number of transactions rejected by the API because of a tag quota.",
+	})
+	serverStreamBroadcastQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_server_stream_broadcast_queue_depth",
+		Help: "Number of broadcast stream submissions waiting for a free worker.",
+	})
+	serverStreamSubscribers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iota_server_stream_subscribers",
+			Help: "Number of connected subscribers per stream route of the API.",
+		},
+		[]string{"route"},
+	)
 
 	registry.MustRegister(serverAllTransactions)
 	registry.MustRegister(serverNewTransactions)
@@ -118,6 +137,9 @@ func init() {
 	registry.MustRegister(serverSentSpamTransactions)
 	registry.MustRegister(serverValidatedBundles)
 	registry.MustRegister(serverSeenSpentAddresses)
+	registry.MustRegister(serverRejectedTagQuotaTxs)
+	registry.MustRegister(serverStreamBroadcastQueueDepth)
+	registry.MustRegister(serverStreamSubscribers)
 
 	addCollect(collectServer)
 }
@@ -141,4 +163,9 @@ func collectServer() {
 	serverSentSpamTransactions.Set(float64(metrics.SharedServerMetrics.SentSpamTransactions.Load()))
 	serverValidatedBundles.Set(float64(metrics.SharedServerMetrics.ValidatedBundles.Load()))
 	serverSeenSpentAddresses.Set(float64(metrics.SharedServerMetrics.SeenSpentAddresses.Load()))
+	serverRejectedTagQuotaTxs.Set(float64(metrics.SharedServerMetrics.RejectedTagQuotaTransactions.Load()))
+	serverStreamBroadcastQueueDepth.Set(float64(metrics.SharedServerMetrics.StreamBroadcastQueueDepth.Load()))
+	for route, subscribers := range webapi.StreamSubscriberCounts() {
+		serverStreamSubscribers.WithLabelValues(route).Set(float64(subscribers))
+	}
 }
@@ -0,0 +1,91 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/tipselect"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+var (
+	tipSelectionTipsNonLazy     prometheus.Gauge
+	tipSelectionTipsSemiLazy    prometheus.Gauge
+	tipSelectionTipsTotal       prometheus.Gauge
+	tipSelectionSelections      prometheus.Gauge
+	tipSelectionNonLazyDuration prometheus.Histogram
+)
+
+// configureTipSelection registers the tip-selection metrics.
+// the metrics are only registered if the tipselection plugin is enabled.
+func configureTipSelection() {
+	if node.IsSkipped(urts.PLUGIN) {
+		return
+	}
+
+	tipSelectionTipsNonLazy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_tipselection_tips_non_lazy",
+		Help: "Number of non-lazy tips in the tip pool.",
+	})
+	tipSelectionTipsSemiLazy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_tipselection_tips_semi_lazy",
+		Help: "Number of semi-lazy tips in the tip pool.",
+	})
+	tipSelectionTipsTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_tipselection_tips_total",
+		Help: "Number of tips in the tip pool.",
+	})
+	tipSelectionSelections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "iota_tipselection_selections",
+		Help: "Number of tip selections performed by the tip-selector.",
+	})
+	tipSelectionNonLazyDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "iota_tipselection_non_lazy_duration_seconds",
+		Help:    "Duration of the selection of non-lazy tips.",
+		Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+	})
+
+	registry.MustRegister(tipSelectionTipsNonLazy)
+	registry.MustRegister(tipSelectionTipsSemiLazy)
+	registry.MustRegister(tipSelectionTipsTotal)
+	registry.MustRegister(tipSelectionSelections)
+	registry.MustRegister(tipSelectionNonLazyDuration)
+
+	addCollect(collectTipSelection)
+}
+
+func collectTipSelection() {
+	nonLazy := metrics.SharedServerMetrics.TipsNonLazy.Load()
+	semiLazy := metrics.SharedServerMetrics.TipsSemiLazy.Load()
+
+	tipSelectionTipsNonLazy.Set(float64(nonLazy))
+	tipSelectionTipsSemiLazy.Set(float64(semiLazy))
+	tipSelectionTipsTotal.Set(float64(nonLazy + semiLazy))
+	tipSelectionSelections.Set(float64(metrics.SharedServerMetrics.TipSelections.Load()))
+}
+
+// runTipSelection observes the duration of every selection of non-lazy tips.
+func runTipSelection() {
+	if tipSelectionNonLazyDuration == nil {
+		// tip-selection metrics are not registered
+		return
+	}
+
+	onTipsSelected := events.NewClosure(func(selection *tipselect.TipSelection) {
+		if selection.Score != tipselect.ScoreNonLazy {
+			return
+		}
+		tipSelectionNonLazyDuration.Observe(selection.Duration.Seconds())
+	})
+
+	daemon.BackgroundWorker("Prometheus[TipSelection]", func(shutdownSignal <-chan struct{}) {
+		urts.TipSelector.Events.TipsSelected.Attach(onTipsSelected)
+		<-shutdownSignal
+		urts.TipSelector.Events.TipsSelected.Detach(onTipsSelected)
+	}, shutdown.PriorityPrometheus)
+}
@@ -0,0 +1,74 @@
+package reattacher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+var (
+	PLUGIN = node.NewPlugin("Reattacher", node.Disabled, configure, run)
+	log    *logger.Logger
+
+	// Events of the reattacher.
+	Events = &ReattacherEvents{
+		BundleConfirmed: events.NewEvent(TrackedBundleCaller),
+	}
+
+	// ErrReattacherDisabled is returned if the reattacher plugin is disabled.
+	ErrReattacherDisabled = errors.New("Reattacher plugin disabled")
+	// ErrTooManyTrackedBundles is returned if the maximum amount of tracked bundles is reached.
+	ErrTooManyTrackedBundles = errors.New("too many tracked bundles")
+	// ErrBundleAlreadyTracked is returned if the bundle is already tracked.
+	ErrBundleAlreadyTracked = errors.New("bundle is already tracked")
+)
+
+// ReattacherEvents are the events issued by the reattacher.
+type ReattacherEvents struct {
+	// Fired when a tracked bundle was confirmed.
+	BundleConfirmed *events.Event
+}
+
+// TrackedBundleCaller is used to signal updates of tracked bundles.
+func TrackedBundleCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*TrackedBundle))(params[0].(*TrackedBundle))
+}
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(plugin.Name)
+
+	// do not enable the reattacher if URTS is disabled
+	if node.IsSkipped(urts.PLUGIN) {
+		plugin.Status = node.Disabled
+		return
+	}
+
+	trackedBundles = make(map[string]*TrackedBundle)
+}
+
+func run(_ *node.Plugin) {
+
+	// do not enable the reattacher if URTS is disabled
+	if node.IsSkipped(urts.PLUGIN) {
+		return
+	}
+
+	checkInterval := time.Duration(config.NodeConfig.GetInt(config.CfgReattacherCheckIntervalSeconds)) * time.Second
+
+	daemon.BackgroundWorker("Reattacher", func(shutdownSignal <-chan struct{}) {
+		log.Info("Starting Reattacher ... done")
+		timeutil.Ticker(func() {
+			checkTrackedBundles(shutdownSignal)
+		}, checkInterval, shutdownSignal)
+		log.Info("Stopping Reattacher ... done")
+	}, shutdown.PriorityReattacher)
+}
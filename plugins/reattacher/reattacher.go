@@ -0,0 +1,390 @@
+package reattacher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/tipselect"
+	"github.com/gohornet/hornet/plugins/curl"
+	"github.com/gohornet/hornet/plugins/gossip"
+	"github.com/gohornet/hornet/plugins/pow"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+// Status is the tracking status of a bundle.
+type Status string
+
+const (
+	// StatusPending means the bundle is not confirmed yet and gets promoted or reattached if needed.
+	StatusPending Status = "pending"
+	// StatusConfirmed means one of the attachments of the bundle was confirmed.
+	StatusConfirmed Status = "confirmed"
+	// StatusConflicting means one of the attachments of the bundle was referenced by a milestone, but it was conflicting.
+	StatusConflicting Status = "conflicting"
+	// StatusExpired means the bundle was not confirmed before the deadline.
+	StatusExpired Status = "expired"
+)
+
+// TrackedBundle is a bundle which gets promoted and reattached by the node until it is confirmed.
+type TrackedBundle struct {
+	BundleHash      trinary.Hash   `json:"bundleHash"`
+	TailHashes      []trinary.Hash `json:"tailHashes"`
+	Status          Status         `json:"status"`
+	Added           int64          `json:"added"`
+	Deadline        int64          `json:"deadline"`
+	Promotions      int            `json:"promotions"`
+	Reattachments   int            `json:"reattachments"`
+	TipReselections int            `json:"tipReselections"`
+	LastError       string         `json:"lastError,omitempty"`
+
+	// the transactions of the bundle sorted by their index
+	txs transaction.Transactions
+}
+
+var (
+	trackedBundles     map[string]*TrackedBundle
+	trackedBundlesLock sync.RWMutex
+)
+
+// Track adds the given bundle to the set of bundles which are promoted and reattached until they are confirmed.
+func Track(txs transaction.Transactions) (*TrackedBundle, error) {
+	if trackedBundles == nil {
+		return nil, ErrReattacherDisabled
+	}
+
+	sortedTxs := make(transaction.Transactions, len(txs))
+	copy(sortedTxs, txs)
+	sort.Slice(sortedTxs, func(i, j int) bool {
+		return sortedTxs[i].CurrentIndex < sortedTxs[j].CurrentIndex
+	})
+
+	trackedBundlesLock.Lock()
+	defer trackedBundlesLock.Unlock()
+
+	if _, exists := trackedBundles[sortedTxs[0].Bundle]; exists {
+		return nil, ErrBundleAlreadyTracked
+	}
+
+	if len(trackedBundles) >= config.NodeConfig.GetInt(config.CfgReattacherMaxTrackedBundles) {
+		// free the slots of bundles which are not tracked anymore
+		for bundleHash, trackedBundle := range trackedBundles {
+			if trackedBundle.Status != StatusPending {
+				delete(trackedBundles, bundleHash)
+			}
+		}
+
+		if len(trackedBundles) >= config.NodeConfig.GetInt(config.CfgReattacherMaxTrackedBundles) {
+			return nil, ErrTooManyTrackedBundles
+		}
+	}
+
+	now := time.Now()
+	trackedBundle := &TrackedBundle{
+		BundleHash: sortedTxs[0].Bundle,
+		TailHashes: []trinary.Hash{sortedTxs[0].Hash},
+		Status:     StatusPending,
+		Added:      now.Unix(),
+		Deadline:   now.Add(time.Duration(config.NodeConfig.GetInt(config.CfgReattacherDeadlineMinutes)) * time.Minute).Unix(),
+		txs:        sortedTxs,
+	}
+	trackedBundles[trackedBundle.BundleHash] = trackedBundle
+
+	return trackedBundle.copy(), nil
+}
+
+// TrackedBundles returns snapshots of all tracked bundles.
+func TrackedBundles() ([]*TrackedBundle, error) {
+	if trackedBundles == nil {
+		return nil, ErrReattacherDisabled
+	}
+
+	trackedBundlesLock.RLock()
+	defer trackedBundlesLock.RUnlock()
+
+	result := make([]*TrackedBundle, 0, len(trackedBundles))
+	for _, trackedBundle := range trackedBundles {
+		result = append(result, trackedBundle.copy())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Added < result[j].Added
+	})
+
+	return result, nil
+}
+
+func (tb *TrackedBundle) copy() *TrackedBundle {
+	c := *tb
+	c.TailHashes = append([]trinary.Hash{}, tb.TailHashes...)
+	c.txs = nil
+	return &c
+}
+
+// checkTrackedBundles promotes or reattaches all pending bundles if needed.
+func checkTrackedBundles(shutdownSignal <-chan struct{}) {
+
+	if !tangle.IsNodeSyncedWithThreshold() {
+		return
+	}
+
+	trackedBundlesLock.RLock()
+	var pendingBundles []*TrackedBundle
+	for _, trackedBundle := range trackedBundles {
+		if trackedBundle.Status == StatusPending {
+			pendingBundles = append(pendingBundles, trackedBundle)
+		}
+	}
+	trackedBundlesLock.RUnlock()
+
+	for _, trackedBundle := range pendingBundles {
+		select {
+		case <-shutdownSignal:
+			return
+		default:
+		}
+
+		checkTrackedBundle(trackedBundle, shutdownSignal)
+	}
+}
+
+// checkTrackedBundle checks the state of the latest attachment of the bundle the same way as getTipInfo does
+// and promotes or reattaches the bundle if needed.
+func checkTrackedBundle(trackedBundle *TrackedBundle, shutdownSignal <-chan struct{}) {
+
+	trackedBundlesLock.RLock()
+	tailHashes := append([]trinary.Hash{}, trackedBundle.TailHashes...)
+	trackedBundlesLock.RUnlock()
+
+	for _, tailHash := range tailHashes {
+		cachedTxMeta := tangle.GetCachedTxMetadataOrNil(hornet.HashFromHashTrytes(tailHash)) // meta +1
+		if cachedTxMeta == nil {
+			continue
+		}
+		confirmed, conflicting := cachedTxMeta.GetMetadata().IsConfirmed(), cachedTxMeta.GetMetadata().IsConflicting()
+		cachedTxMeta.Release(true) // meta -1
+
+		switch {
+		case conflicting:
+			setStatus(trackedBundle, StatusConflicting)
+			return
+		case confirmed:
+			setStatus(trackedBundle, StatusConfirmed)
+			Events.BundleConfirmed.Trigger(trackedBundle.copy())
+			return
+		}
+	}
+
+	if time.Now().Unix() > trackedBundle.Deadline {
+		setStatus(trackedBundle, StatusExpired)
+		return
+	}
+
+	latestTailHash := hornet.HashFromHashTrytes(tailHashes[len(tailHashes)-1])
+
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(latestTailHash) // meta +1
+	if cachedTxMeta == nil || !cachedTxMeta.GetMetadata().IsSolid() {
+		if cachedTxMeta != nil {
+			cachedTxMeta.Release(true) // meta -1
+		}
+		// wait until the latest attachment is solid
+		return
+	}
+
+	lsmi := tangle.GetSolidMilestoneIndex()
+	ytrsi, ortsi := dag.GetTransactionRootSnapshotIndexes(cachedTxMeta, lsmi) // meta pass +1
+
+	var err error
+	var retries int
+	switch {
+	case (lsmi - ortsi) > milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelBelowMaxDepth)):
+		// the tip is lazy and should be reattached
+		var tailHash trinary.Hash
+		if tailHash, retries, err = reattach(trackedBundle, shutdownSignal); err == nil {
+			trackedBundlesLock.Lock()
+			trackedBundle.TailHashes = append(trackedBundle.TailHashes, tailHash)
+			trackedBundle.Reattachments++
+			trackedBundlesLock.Unlock()
+		}
+
+	case (lsmi - ytrsi) > milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelMaxDeltaTxYoungestRootSnapshotIndexToLSMI)),
+		(lsmi - ortsi) > milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelMaxDeltaTxOldestRootSnapshotIndexToLSMI)):
+		// the tip is semi-lazy and should be promoted
+		if retries, err = promote(latestTailHash, shutdownSignal); err == nil {
+			trackedBundlesLock.Lock()
+			trackedBundle.Promotions++
+			trackedBundlesLock.Unlock()
+		}
+
+	default:
+		// the tip is non-lazy, no need to promote or reattach
+		return
+	}
+
+	trackedBundlesLock.Lock()
+	trackedBundle.TipReselections += retries
+	trackedBundle.LastError = ""
+	if err != nil {
+		log.Warnf("promotion/reattachment of bundle %s failed: %s", trackedBundle.BundleHash, err)
+		trackedBundle.LastError = err.Error()
+	}
+	trackedBundlesLock.Unlock()
+}
+
+func setStatus(trackedBundle *TrackedBundle, status Status) {
+	trackedBundlesLock.Lock()
+	defer trackedBundlesLock.Unlock()
+	trackedBundle.Status = status
+}
+
+// reattach attaches the transactions of the bundle on top of new tips.
+// it returns the hash of the new tail transaction and the amount of tip reselections.
+func reattach(trackedBundle *TrackedBundle, shutdownSignal <-chan struct{}) (trinary.Hash, int, error) {
+	return attachWithTipReselection(trackedBundle.txs, nil, shutdownSignal)
+}
+
+// promote issues a zero value transaction which approves the given tail transaction and a non-lazy tip.
+// it returns the amount of tip reselections.
+func promote(tailHash hornet.Hash, shutdownSignal <-chan struct{}) (int, error) {
+	tag := trinary.MustPad(config.NodeConfig.GetString(config.CfgReattacherPromotionTag), consts.TagTrinarySize/3)[:consts.TagTrinarySize/3]
+
+	txs, err := bundle.Finalize(bundle.Bundle{
+		transaction.Transaction{
+			SignatureMessageFragment:      trinary.MustPad("", consts.SignatureMessageFragmentTrinarySize/3),
+			Address:                       consts.NullHashTrytes,
+			Value:                         0,
+			ObsoleteTag:                   tag,
+			Timestamp:                     uint64(time.Now().Unix()),
+			CurrentIndex:                  0,
+			LastIndex:                     0,
+			Bundle:                        consts.NullHashTrytes,
+			TrunkTransaction:              consts.NullHashTrytes,
+			BranchTransaction:             consts.NullHashTrytes,
+			Tag:                           tag,
+			AttachmentTimestamp:           0,
+			AttachmentTimestampLowerBound: consts.LowerBoundAttachmentTimestamp,
+			AttachmentTimestampUpperBound: consts.UpperBoundAttachmentTimestamp,
+			Nonce:                         consts.NullTagTrytes,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, retries, err := attachWithTipReselection(txs, tailHash, shutdownSignal)
+	return retries, err
+}
+
+// attachWithTipReselection selects non-lazy tips, attaches the given transactions (sorted by their index) on top of them
+// and emits the resulting transactions. if branch is given, only the trunk is selected.
+// the PoW takes some time, so the selected tips could already be lazy once it is done. in that case the tips are
+// selected again and the PoW is redone, at most "reattacher.tipReselectionRetries" times.
+// It returns the hash of the new tail transaction and the amount of tip reselections.
+func attachWithTipReselection(txs transaction.Transactions, branch hornet.Hash, shutdownSignal <-chan struct{}) (trinary.Hash, int, error) {
+	maxRetries := config.NodeConfig.GetInt(config.CfgReattacherTipReselectionRetries)
+
+	for retries := 0; ; retries++ {
+		tips, err := urts.TipSelector.SelectNonLazyTips()
+		if err != nil {
+			return "", retries, err
+		}
+
+		selectedTips := tips
+		branchHash := tips[len(tips)-1]
+		if branch != nil {
+			selectedTips = tips[:1]
+			branchHash = branch
+		}
+
+		attachedTxs := make(transaction.Transactions, len(txs))
+		copy(attachedTxs, txs)
+
+		if err := attach(attachedTxs, tips[0].Trytes(), branchHash.Trytes(), shutdownSignal); err != nil {
+			return "", retries, err
+		}
+
+		if retries < maxRetries && anyTipLazy(selectedTips) {
+			// the attachment would be lazy right away
+			continue
+		}
+
+		for i := range attachedTxs {
+			txTrits, _ := transaction.TransactionToTrits(&attachedTxs[i])
+			if err := gossip.Processor().CompressAndEmit(&attachedTxs[i], txTrits); err != nil {
+				return "", retries, err
+			}
+		}
+
+		return attachedTxs[0].Hash, retries, nil
+	}
+}
+
+// anyTipLazy checks whether one of the given tips is lazy by now.
+func anyTipLazy(tips hornet.Hashes) bool {
+	for _, tip := range tips {
+		if urts.TipSelector.CalculateScore(tip) == tipselect.ScoreLazy {
+			return true
+		}
+	}
+	return false
+}
+
+// attach does the PoW for the given transactions (sorted by their index) the same way attachToTangle does.
+func attach(txs transaction.Transactions, trunk trinary.Hash, branch trinary.Hash, shutdownSignal <-chan struct{}) error {
+	mwm := config.NodeConfig.GetInt(config.CfgCoordinatorMWM)
+
+	var prev trinary.Hash
+	for i := len(txs) - 1; i >= 0; i-- {
+		switch {
+		case i == len(txs)-1:
+			txs[i].TrunkTransaction = trunk
+			txs[i].BranchTransaction = branch
+		default:
+			txs[i].TrunkTransaction = prev
+			txs[i].BranchTransaction = trunk
+		}
+
+		txs[i].AttachmentTimestamp = time.Now().UnixNano() / int64(time.Millisecond)
+		txs[i].AttachmentTimestampLowerBound = consts.LowerBoundAttachmentTimestamp
+		txs[i].AttachmentTimestampUpperBound = consts.UpperBoundAttachmentTimestamp
+
+		trytes, err := transaction.TransactionToTrytes(&txs[i])
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-shutdownSignal:
+			return tangle.ErrOperationAborted
+		default:
+		}
+
+		if txs[i].Nonce, err = pow.Handler().DoPoW(trytes, mwm); err != nil {
+			return err
+		}
+
+		txTrits, err := transaction.TransactionToTrits(&txs[i])
+		if err != nil {
+			return err
+		}
+
+		hashTrits, err := curl.Hasher().Hash(txTrits)
+		if err != nil {
+			return err
+		}
+		txs[i].Hash = trinary.MustTritsToTrytes(hashTrits)
+		prev = txs[i].Hash
+	}
+
+	return nil
+}
@@ -39,6 +39,30 @@ var (
 	ErrApproverTxNotFound       = errors.New("approver transaction not found")
 )
 
+// SnapshotStage defines a stage of the local snapshot creation.
+type SnapshotStage string
+
+const (
+	// SnapshotStageLedgerState is reported while the ledger state is rolled back to the target milestone.
+	SnapshotStageLedgerState SnapshotStage = "ledgerState"
+	// SnapshotStageSolidEntryPoints is reported for every milestone which is searched for solid entry points.
+	SnapshotStageSolidEntryPoints SnapshotStage = "solidEntryPoints"
+	// SnapshotStageSeenMilestones is reported for every milestone above the target milestone which is collected.
+	SnapshotStageSeenMilestones SnapshotStage = "seenMilestones"
+	// SnapshotStageWriteFile is reported while the snapshot file is written.
+	SnapshotStageWriteFile SnapshotStage = "writeFile"
+)
+
+// SnapshotProgressFunc is called with the current stage and the processed milestone during the local snapshot creation.
+type SnapshotProgressFunc func(stage SnapshotStage, msIndex milestone.Index)
+
+// reportProgress calls the progress func if it is set.
+func reportProgress(progress SnapshotProgressFunc, stage SnapshotStage, msIndex milestone.Index) {
+	if progress != nil {
+		progress(stage, msIndex)
+	}
+}
+
 // isSolidEntryPoint checks whether any direct approver of the given transaction was confirmed by a milestone which is above the target milestone.
 func isSolidEntryPoint(txHash hornet.Hash, targetIndex milestone.Index) bool {
 
@@ -173,7 +197,7 @@ func shouldTakeSnapshot(solidMilestoneIndex milestone.Index) bool {
 	return solidMilestoneIndex-(snapshotDepth+snapshotInterval) >= snapshotInfo.SnapshotIndex
 }
 
-func getSolidEntryPoints(targetIndex milestone.Index, abortSignal <-chan struct{}) (map[string]milestone.Index, error) {
+func getSolidEntryPoints(targetIndex milestone.Index, abortSignal <-chan struct{}, progress SnapshotProgressFunc) (map[string]milestone.Index, error) {
 
 	solidEntryPoints := make(map[string]milestone.Index)
 
@@ -189,6 +213,8 @@ func getSolidEntryPoints(targetIndex milestone.Index, abortSignal <-chan struct{
 		default:
 		}
 
+		reportProgress(progress, SnapshotStageSolidEntryPoints, milestoneIndex)
+
 		cachedMs := tangle.GetMilestoneOrNil(milestoneIndex) // bundle +1
 		if cachedMs == nil {
 			return nil, errors.Wrapf(ErrCritical, "milestone (%d) not found!", milestoneIndex)
@@ -239,7 +265,7 @@ func getSolidEntryPoints(targetIndex milestone.Index, abortSignal <-chan struct{
 	return solidEntryPoints, nil
 }
 
-func getSeenMilestones(targetIndex milestone.Index, abortSignal <-chan struct{}) (map[string]milestone.Index, error) {
+func getSeenMilestones(targetIndex milestone.Index, abortSignal <-chan struct{}, progress SnapshotProgressFunc) (map[string]milestone.Index, error) {
 
 	// Fill the list with seen milestones
 	seenMilestones := make(map[string]milestone.Index)
@@ -251,6 +277,8 @@ func getSeenMilestones(targetIndex milestone.Index, abortSignal <-chan struct{})
 		default:
 		}
 
+		reportProgress(progress, SnapshotStageSeenMilestones, milestoneIndex)
+
 		cachedMs := tangle.GetMilestoneOrNil(milestoneIndex) // bundle +1
 		if cachedMs == nil {
 			continue
@@ -377,7 +405,59 @@ func setIsSnapshotting(value bool) {
 	statusLock.Unlock()
 }
 
-func createLocalSnapshotWithoutLocking(targetIndex milestone.Index, filePath string, writeToDatabase bool, abortSignal <-chan struct{}) error {
+// collectLocalSnapshotState collects the ledger state, the solid entry points and the seen milestones of a local snapshot.
+//
+// if "snapshots.local.lockLedger" is enabled, the ledger is read locked until all parts are collected.
+// milestones which get solid in the meantime are confirmed after the state was collected, and transactions
+// which arrive in the meantime are stored as usual, since only confirmed transactions are part of the snapshot.
+// this guarantees that the ledger state and the solid entry points refer to the same confirmed state of the tangle.
+// otherwise the solid entry points may already contain transactions which were confirmed during the snapshot creation.
+func collectLocalSnapshotState(targetIndex milestone.Index, abortSignal <-chan struct{}, progress SnapshotProgressFunc) (map[string]uint64, map[string]milestone.Index, map[string]milestone.Index, error) {
+
+	lockLedger := config.NodeConfig.GetBool(config.CfgLocalSnapshotsLockLedger)
+	if lockLedger {
+		tangle.ReadLockLedger()
+		defer tangle.ReadUnlockLedger()
+	}
+
+	reportProgress(progress, SnapshotStageLedgerState, targetIndex)
+
+	var newBalances map[string]uint64
+	var ledgerIndex milestone.Index
+	var err error
+	if lockLedger {
+		newBalances, ledgerIndex, err = tangle.GetLedgerStateForMilestoneWithoutLocking(targetIndex, abortSignal)
+	} else {
+		newBalances, ledgerIndex, err = tangle.GetLedgerStateForMilestone(targetIndex, abortSignal)
+	}
+	if err != nil {
+		if err == tangle.ErrOperationAborted {
+			return nil, nil, nil, err
+		}
+		return nil, nil, nil, errors.Wrap(ErrCritical, err.Error())
+	}
+
+	if ledgerIndex != targetIndex {
+		return nil, nil, nil, errors.Wrapf(ErrCritical, "ledger index wrong! %d/%d", ledgerIndex, targetIndex)
+	}
+
+	newSolidEntryPoints, err := getSolidEntryPoints(targetIndex, abortSignal, progress)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	seenMilestones, err := getSeenMilestones(targetIndex, abortSignal, progress)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return newBalances, newSolidEntryPoints, seenMilestones, nil
+}
+
+// createLocalSnapshotWithoutLocking creates a local snapshot file for the target index.
+// the optional progress func is called for every processed milestone.
+// if the creation fails or is aborted, the partially written file is removed.
+func createLocalSnapshotWithoutLocking(targetIndex milestone.Index, filePath string, writeToDatabase bool, abortSignal <-chan struct{}, progress SnapshotProgressFunc) error {
 
 	log.Infof("creating local snapshot for targetIndex %d", targetIndex)
 
@@ -401,24 +481,7 @@ func createLocalSnapshotWithoutLocking(targetIndex milestone.Index, filePath str
 	}
 	defer cachedTargetMs.Release(true) // bundle -1
 
-	newBalances, ledgerIndex, err := tangle.GetLedgerStateForMilestone(targetIndex, abortSignal)
-	if err != nil {
-		if err == tangle.ErrOperationAborted {
-			return err
-		}
-		return errors.Wrap(ErrCritical, err.Error())
-	}
-
-	if ledgerIndex != targetIndex {
-		return errors.Wrapf(ErrCritical, "ledger index wrong! %d/%d", ledgerIndex, targetIndex)
-	}
-
-	newSolidEntryPoints, err := getSolidEntryPoints(targetIndex, abortSignal)
-	if err != nil {
-		return err
-	}
-
-	seenMilestones, err := getSeenMilestones(targetIndex, abortSignal)
+	newBalances, newSolidEntryPoints, seenMilestones, err := collectLocalSnapshotState(targetIndex, abortSignal, progress)
 	if err != nil {
 		return err
 	}
@@ -440,8 +503,12 @@ func createLocalSnapshotWithoutLocking(targetIndex milestone.Index, filePath str
 	// Remove old temp file
 	os.Remove(filePathTmp)
 
+	reportProgress(progress, SnapshotStageWriteFile, targetIndex)
+
 	hash, err := createSnapshotFile(filePathTmp, lsh, abortSignal)
 	if err != nil {
+		// Remove the partially written file
+		os.Remove(filePathTmp)
 		return err
 	}
 
@@ -471,10 +538,10 @@ func createLocalSnapshotWithoutLocking(targetIndex milestone.Index, filePath str
 	return nil
 }
 
-func CreateLocalSnapshot(targetIndex milestone.Index, filePath string, writeToDatabase bool, abortSignal <-chan struct{}) error {
+func CreateLocalSnapshot(targetIndex milestone.Index, filePath string, writeToDatabase bool, abortSignal <-chan struct{}, progress SnapshotProgressFunc) error {
 	localSnapshotLock.Lock()
 	defer localSnapshotLock.Unlock()
-	return createLocalSnapshotWithoutLocking(targetIndex, filePath, writeToDatabase, abortSignal)
+	return createLocalSnapshotWithoutLocking(targetIndex, filePath, writeToDatabase, abortSignal, progress)
 }
 
 type localSnapshotHeader struct {
@@ -156,6 +156,13 @@ func configure(plugin *node.Plugin) {
 	}
 }
 
+// IsSnapshotting returns whether a local snapshot is currently created.
+func IsSnapshotting() bool {
+	statusLock.RLock()
+	defer statusLock.RUnlock()
+	return isSnapshotting
+}
+
 func isSnapshottingOrPruning() bool {
 	statusLock.RLock()
 	defer statusLock.RUnlock()
@@ -189,7 +196,7 @@ func run(_ *node.Plugin) {
 
 				if shouldTakeSnapshot(solidMilestoneIndex) {
 					localSnapshotPath := config.NodeConfig.GetString(config.CfgLocalSnapshotsPath)
-					if err := createLocalSnapshotWithoutLocking(solidMilestoneIndex-snapshotDepth, localSnapshotPath, true, shutdownSignal); err != nil {
+					if err := createLocalSnapshotWithoutLocking(solidMilestoneIndex-snapshotDepth, localSnapshotPath, true, shutdownSignal, nil); err != nil {
 						if errors.Is(err, ErrCritical) {
 							log.Panic(errors.Wrap(ErrSnapshotCreationFailed, err.Error()))
 						}
@@ -159,7 +159,7 @@ func pruneDatabase(targetIndex milestone.Index, abortSignal <-chan struct{}) err
 	defer setIsPruning(false)
 
 	// calculate solid entry points for the new end of the tangle history
-	newSolidEntryPoints, err := getSolidEntryPoints(targetIndex, abortSignal)
+	newSolidEntryPoints, err := getSolidEntryPoints(targetIndex, abortSignal, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,81 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+const (
+	// ArrivalSourceLocal is the arrival source of transactions which were not received via gossip,
+	// e.g. transactions submitted via the API or issued by the node itself.
+	ArrivalSourceLocal = "local"
+	// ArrivalSourceUnknown is the arrival source of transactions which are not tracked (anymore).
+	ArrivalSourceUnknown = "unknown"
+)
+
+var (
+	arrivalSourcesLock sync.RWMutex
+	// arrivalSources maps the hashes of recently received transactions to the ID of the peer which delivered them first.
+	arrivalSources map[string]string
+	// arrivalSourcesOrder contains the tracked transaction hashes in the order of their arrival,
+	// the oldest entry is evicted if the window is full.
+	arrivalSourcesOrder *utils.RingBuffer
+)
+
+func configureArrivalSources() {
+	window := config.NodeConfig.GetInt(config.CfgTangleArrivalSourcesWindow)
+	if window <= 0 {
+		// tracking is disabled by default because of the memory cost
+		return
+	}
+
+	arrivalSources = make(map[string]string, window)
+	arrivalSourcesOrder = utils.NewRingBuffer(window)
+}
+
+// recordArrivalSource records the peer which delivered the newly added transaction.
+func recordArrivalSource(txHash hornet.Hash, p *peer.Peer) {
+	if arrivalSources == nil {
+		return
+	}
+
+	source := ArrivalSourceLocal
+	if p != nil {
+		source = p.ID
+	}
+
+	arrivalSourcesLock.Lock()
+	defer arrivalSourcesLock.Unlock()
+
+	if _, exists := arrivalSources[string(txHash)]; exists {
+		return
+	}
+
+	if evicted := arrivalSourcesOrder.Add(txHash); evicted != nil {
+		delete(arrivalSources, string(evicted.(hornet.Hash)))
+	}
+
+	arrivalSources[string(txHash)] = source
+}
+
+// IsArrivalSourceTrackingEnabled returns whether the arrival sources of received transactions are tracked.
+func IsArrivalSourceTrackingEnabled() bool {
+	return arrivalSources != nil
+}
+
+// GetArrivalSource returns the ID of the peer which delivered the given transaction first,
+// ArrivalSourceLocal if the transaction was not received via gossip,
+// or ArrivalSourceUnknown if the transaction is not within the tracked window.
+func GetArrivalSource(txHash hornet.Hash) string {
+	arrivalSourcesLock.RLock()
+	defer arrivalSourcesLock.RUnlock()
+
+	if source, exists := arrivalSources[string(txHash)]; exists {
+		return source
+	}
+	return ArrivalSourceUnknown
+}
@@ -0,0 +1,58 @@
+package tangle
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+var (
+	confirmationLatencies *utils.LatencyBuffer
+)
+
+func configureConfirmationLatency() {
+	confirmationLatencies = utils.NewLatencyBuffer(config.NodeConfig.GetInt(config.CfgTangleConfirmationLatencyWindow))
+}
+
+func runConfirmationLatency() {
+	onTransactionConfirmed := events.NewClosure(func(cachedMeta *tangle.CachedMetadata, _ milestone.Index, confTime int64) {
+		defer cachedMeta.Release(true) // meta -1
+
+		// latencies are only meaningful if the transactions arrived in realtime
+		if !tangle.IsNodeSyncedWithThreshold() {
+			return
+		}
+
+		meta := cachedMeta.GetMetadata()
+		if meta.IsConflicting() || !meta.IsTail() {
+			return
+		}
+
+		solidificationTimestamp := int64(meta.GetSolidificationTimestamp())
+		if solidificationTimestamp == 0 || confTime < solidificationTimestamp {
+			return
+		}
+
+		confirmationLatencies.Add(time.Duration(confTime-solidificationTimestamp) * time.Second)
+	})
+
+	daemon.BackgroundWorker("Tangle[ConfirmationLatency]", func(shutdownSignal <-chan struct{}) {
+		Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+		<-shutdownSignal
+		Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+	}, shutdown.PriorityMetricsUpdater)
+}
+
+// GetConfirmationLatencyPercentiles returns the given percentiles of the time between the arrival (solidification)
+// of recently confirmed tail transactions and the timestamp of the milestone that confirmed them,
+// and the amount of samples they were calculated from.
+func GetConfirmationLatencyPercentiles(percentiles ...float64) ([]time.Duration, int) {
+	return confirmationLatencies.Percentiles(percentiles...)
+}
@@ -0,0 +1,69 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/utils"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+)
+
+var (
+	conflictCountsLock sync.RWMutex
+	conflictCounts     *utils.RingBuffer
+)
+
+// MilestoneConflictCount is the amount of conflicting bundles which were referenced by a milestone.
+type MilestoneConflictCount struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Conflicting    int             `json:"conflicting"`
+}
+
+func configureConflictCounts() {
+	conflictCounts = utils.NewRingBuffer(config.NodeConfig.GetInt(config.CfgTangleConflictsWindow))
+}
+
+func runConflictCounts() {
+	onMilestoneConfirmed := events.NewClosure(func(confirmation *whiteflag.Confirmation) {
+		addConflictCount(&MilestoneConflictCount{
+			MilestoneIndex: confirmation.MilestoneIndex,
+			Conflicting:    len(confirmation.Mutations.TailsExcludedConflicting),
+		})
+	})
+
+	daemon.BackgroundWorker("Tangle[ConflictCounts]", func(shutdownSignal <-chan struct{}) {
+		Events.MilestoneConfirmed.Attach(onMilestoneConfirmed)
+		<-shutdownSignal
+		Events.MilestoneConfirmed.Detach(onMilestoneConfirmed)
+	}, shutdown.PriorityMetricsUpdater)
+}
+
+func addConflictCount(count *MilestoneConflictCount) {
+	conflictCountsLock.Lock()
+	defer conflictCountsLock.Unlock()
+
+	conflictCounts.Add(count)
+}
+
+// GetConflictCounts returns the amount of conflicting bundles of the recently confirmed milestones, ordered by
+// milestone index, and the sum of them. The amount of considered milestones is configured by "tangle.conflictsWindow".
+func GetConflictCounts() ([]*MilestoneConflictCount, int) {
+	conflictCountsLock.RLock()
+	defer conflictCountsLock.RUnlock()
+
+	result := make([]*MilestoneConflictCount, 0, conflictCounts.Len())
+	total := 0
+	conflictCounts.ForEachOldestFirst(func(element interface{}) bool {
+		count := element.(*MilestoneConflictCount)
+		result = append(result, count)
+		total += count.Conflicting
+		return true
+	})
+
+	return result, total
+}
@@ -23,6 +23,11 @@ func IsNodeHealthy() bool {
 		return false
 	}
 
+	return IsLatestMilestoneRecent()
+}
+
+// IsLatestMilestoneRecent returns whether the latest milestone is not older than 5 minutes.
+func IsLatestMilestoneRecent() bool {
 	// Latest milestone timestamp
 	var milestoneTimestamp int64
 	lmi := tangle.GetLatestMilestoneIndex()
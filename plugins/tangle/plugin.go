@@ -80,6 +80,11 @@ func configure(plugin *node.Plugin) {
 
 	configureEvents()
 	configureTangleProcessor(plugin)
+	configureConfirmationLatency()
+	configureConflictCounts()
+	configureArrivalSources()
+	configureTransactionSizes()
+	configureRecentConfirmations()
 
 	gossip.AddRequestBackpressureSignal(IsReceiveTxWorkerPoolBusy)
 }
@@ -176,6 +181,9 @@ func run(plugin *node.Plugin) {
 	tangle.SetLatestMilestoneIndex(latestMilestoneFromDatabase, updateSyncedAtStartup)
 
 	runTangleProcessor(plugin)
+	runConfirmationLatency()
+	runConflictCounts()
+	runRecentConfirmations()
 
 	// create a background worker that prints a status message every second
 	daemon.BackgroundWorker("Tangle status reporter", func(shutdownSignal <-chan struct{}) {
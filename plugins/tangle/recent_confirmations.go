@@ -0,0 +1,70 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+var (
+	recentConfirmationsLock sync.RWMutex
+	recentConfirmations     *utils.RingBuffer
+)
+
+// ConfirmedTransaction is a recently confirmed transaction and the index of the milestone that confirmed it.
+type ConfirmedTransaction struct {
+	Hash           trinary.Hash    `json:"hash"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+}
+
+func configureRecentConfirmations() {
+	recentConfirmations = utils.NewRingBuffer(config.NodeConfig.GetInt(config.CfgTangleRecentConfirmationsWindow))
+}
+
+func runRecentConfirmations() {
+	onTransactionConfirmed := events.NewClosure(func(cachedMeta *tangle.CachedMetadata, msIndex milestone.Index, _ int64) {
+		defer cachedMeta.Release(true) // meta -1
+
+		confirmedTx := &ConfirmedTransaction{Hash: cachedMeta.GetMetadata().GetTxHash().Trytes(), MilestoneIndex: msIndex}
+
+		recentConfirmationsLock.Lock()
+		recentConfirmations.Add(confirmedTx)
+		recentConfirmationsLock.Unlock()
+	})
+
+	daemon.BackgroundWorker("Tangle[RecentConfirmations]", func(shutdownSignal <-chan struct{}) {
+		Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+		<-shutdownSignal
+		Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+	}, shutdown.PriorityMetricsUpdater)
+}
+
+// GetRecentConfirmations returns up to limit of the most recently confirmed transactions, newest first.
+// the amount of kept transactions is configured by "tangle.recentConfirmationsWindow".
+func GetRecentConfirmations(limit int) []*ConfirmedTransaction {
+	recentConfirmationsLock.RLock()
+	defer recentConfirmationsLock.RUnlock()
+
+	if limit > recentConfirmations.Len() {
+		limit = recentConfirmations.Len()
+	}
+
+	result := make([]*ConfirmedTransaction, 0, limit)
+	recentConfirmations.ForEachNewestFirst(func(element interface{}) bool {
+		if len(result) >= limit {
+			return false
+		}
+		result = append(result, element.(*ConfirmedTransaction))
+		return true
+	})
+
+	return result
+}
@@ -212,6 +212,92 @@ func solidQueueCheck(cachedTxMetas map[string]*tangle.CachedMetadata, milestoneI
 	return true, false
 }
 
+// SolidifySubtangle re-evaluates the solidity of the given transaction and its past cone.
+// Missing transactions are requested again and returned.
+// If no transactions are missing, the past cone and the future cone of the transaction are marked as solid.
+func SolidifySubtangle(txHash hornet.Hash, abortSignal <-chan struct{}) (hornet.Hashes, error) {
+
+	// the milestone solidifier must not run in parallel
+	solidifierLock.Lock()
+	defer solidifierLock.Unlock()
+
+	cachedTxMetas := make(map[string]*tangle.CachedMetadata)
+
+	defer func() {
+		// release all tx metadata at the end
+		for _, cachedTxMeta := range cachedTxMetas {
+			cachedTxMeta.Release(true) // meta -1
+		}
+	}()
+
+	var txsToSolidify hornet.Hashes
+	txsToRequest := make(map[string]struct{})
+
+	if err := dag.TraverseApprovees(txHash,
+		// traversal stops if no more transactions pass the given condition
+		// Caution: condition func is not in DFS order
+		func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+
+			if _, exists := cachedTxMetas[string(cachedTxMeta.GetMetadata().GetTxHash())]; !exists {
+				// release the tx metadata at the end to speed up calculation
+				cachedTxMetas[string(cachedTxMeta.GetMetadata().GetTxHash())] = cachedTxMeta.Retain()
+			}
+
+			// if the tx is solid, there is no need to traverse its approvees
+			return !cachedTxMeta.GetMetadata().IsSolid(), nil
+		},
+		// consumer
+		func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+
+			// collect the txToSolidify in an ordered way
+			txsToSolidify = append(txsToSolidify, cachedTxMeta.GetMetadata().GetTxHash())
+
+			return nil
+		},
+		// called on missing approvees
+		func(approveeHash hornet.Hash) error {
+			// tx does not exist => request missing tx
+			txsToRequest[string(approveeHash)] = struct{}{}
+			return nil
+		},
+		// called on solid entry points
+		// Ignore solid entry points (snapshot milestone included)
+		nil,
+		false, false, abortSignal); err != nil {
+		return nil, err
+	}
+
+	if len(txsToRequest) > 0 {
+		var missingTxHashes hornet.Hashes
+		for missingTxHash := range txsToRequest {
+			missingTxHashes = append(missingTxHashes, hornet.Hash(missingTxHash))
+		}
+		requested := gossip.RequestMultiple(missingTxHashes, tangle.GetLatestMilestoneIndex(), true)
+		log.Infof("Subtangle solidification of %v stopped due to missing tx -> Requested missing txs (%d/%d)", txHash.Trytes(), requested, len(missingTxHashes))
+		return missingTxHashes, nil
+	}
+
+	// no transactions to request => the whole cone is solid
+	// we mark all transactions as solid in order from oldest to latest (needed for the tip pool)
+	for _, txHashToSolidify := range txsToSolidify {
+		cachedTxMeta, exists := cachedTxMetas[string(txHashToSolidify)]
+		if !exists {
+			return nil, fmt.Errorf("SolidifySubtangle: Tx not found: %v", txHashToSolidify.Trytes())
+		}
+
+		markTransactionAsSolid(cachedTxMeta.Retain())
+	}
+
+	// propagate solidity to the future cone (txs attached to the txs of this subtangle)
+	if err := solidifyFutureCone(cachedTxMetas, txsToSolidify, nil); err != nil {
+		return nil, err
+	}
+
+	return hornet.Hashes{}, nil
+}
+
 // solidifyFutureConeOfTx updates the solidity of the future cone (transactions approving the given transaction).
 // we have to walk the future cone, if a transaction became newly solid during the walk.
 func solidifyFutureConeOfTx(cachedTxMeta *tangle.CachedMetadata) error {
@@ -132,6 +132,21 @@ func IsReceiveTxWorkerPoolBusy() bool {
 	return receiveTxWorkerPool.GetPendingQueueSize() > (receiveTxQueueSize / 2)
 }
 
+// GetReceiveTxQueueSize returns the amount of received transactions which wait for being processed and solidified.
+func GetReceiveTxQueueSize() int {
+	return receiveTxWorkerPool.GetPendingQueueSize()
+}
+
+// GetMilestonesToSolidifyCount returns the amount of known milestones which are not solid yet.
+func GetMilestonesToSolidifyCount() milestone.Index {
+	lmi := tangle.GetLatestMilestoneIndex()
+	lsmi := tangle.GetSolidMilestoneIndex()
+	if lmi <= lsmi {
+		return 0
+	}
+	return lmi - lsmi
+}
+
 func processIncomingTx(incomingTx *hornet.Transaction, request *rqueue.Request, p *peer.Peer) {
 
 	latestMilestoneIndex := tangle.GetLatestMilestoneIndex()
@@ -145,6 +160,8 @@ func processIncomingTx(incomingTx *hornet.Transaction, request *rqueue.Request,
 
 	if !alreadyAdded {
 		metrics.SharedServerMetrics.NewTransactions.Inc()
+		recordArrivalSource(incomingTx.GetTxHash(), p)
+		recordTransactionSize(len(incomingTx.RawBytes))
 
 		if p != nil {
 			p.Metrics.NewTransactions.Inc()
@@ -0,0 +1,75 @@
+package tangle
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/utils"
+)
+
+var (
+	transactionSizesLock sync.RWMutex
+	transactionSizes     *utils.RingBuffer
+	// transactionSizesSum is updated with every added sample, so the average doesn't need to iterate the window.
+	transactionSizesSum int64
+)
+
+// TransactionSizeStats are the statistics of the stored sizes of recently received transactions.
+type TransactionSizeStats struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Min     int     `json:"min"`
+	Max     int     `json:"max"`
+	P95     int     `json:"p95"`
+}
+
+func configureTransactionSizes() {
+	transactionSizes = utils.NewRingBuffer(config.NodeConfig.GetInt(config.CfgTangleTransactionSizesWindow))
+}
+
+// recordTransactionSize adds the stored size of a newly added transaction to the window.
+func recordTransactionSize(size int) {
+	transactionSizesLock.Lock()
+	defer transactionSizesLock.Unlock()
+
+	transactionSizesSum += int64(size)
+	if evicted := transactionSizes.Add(size); evicted != nil {
+		transactionSizesSum -= int64(evicted.(int))
+	}
+}
+
+// GetTransactionSizeStats returns the statistics of the stored sizes in bytes of the recently received transactions.
+// the amount of considered transactions is configured by "tangle.transactionSizesWindow".
+// all values are zero if no transaction was received yet.
+func GetTransactionSizeStats() *TransactionSizeStats {
+	transactionSizesLock.RLock()
+	count := transactionSizes.Len()
+	sum := transactionSizesSum
+	sorted := make([]int, 0, count)
+	transactionSizes.ForEachOldestFirst(func(element interface{}) bool {
+		sorted = append(sorted, element.(int))
+		return true
+	})
+	transactionSizesLock.RUnlock()
+
+	if count == 0 {
+		return &TransactionSizeStats{}
+	}
+
+	sort.Ints(sorted)
+
+	// nearest-rank method
+	p95Rank := int(0.95*float64(count)+0.5) - 1
+	if p95Rank < 0 {
+		p95Rank = 0
+	}
+
+	return &TransactionSizeStats{
+		Count:   count,
+		Average: float64(sum) / float64(count),
+		Min:     sorted[0],
+		Max:     sorted[count-1],
+		P95:     sorted[p95Rank],
+	}
+}
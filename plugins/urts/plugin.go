@@ -45,8 +45,14 @@ func configure(plugin *node.Plugin) {
 		time.Duration(time.Second*time.Duration(config.NodeConfig.GetInt(config.CfgTipSelSemiLazy+config.CfgTipSelMaxReferencedTipAgeSeconds))),
 		config.NodeConfig.GetUint32(config.CfgTipSelSemiLazy+config.CfgTipSelMaxApprovers),
 		config.NodeConfig.GetInt(config.CfgTipSelSemiLazy+config.CfgTipSelSpammerTipsThreshold),
+
+		config.NodeConfig.GetBool(config.CfgTipSelAllowUnsynced),
 	)
 
+	if TipSelector.AllowUnsynced() {
+		log.Warn("tip selection during sync is enabled, transactions attached during sync may be orphaned")
+	}
+
 	configureEvents()
 }
 
@@ -74,8 +80,8 @@ func run(_ *node.Plugin) {
 func configureEvents() {
 	onBundleSolid = events.NewClosure(func(cachedBndl *tangle.CachedBundle) {
 		cachedBndl.ConsumeBundle(func(bndl *tangle.Bundle) { // bundle -1
-			// do not add tips during syncing, because it is not needed at all
-			if !tangle.IsNodeSyncedWithThreshold() {
+			// do not add tips during syncing, because it is not needed at all (unless tip selection during sync is allowed)
+			if !TipSelector.AllowUnsynced() && !tangle.IsNodeSyncedWithThreshold() {
 				return
 			}
 
@@ -89,8 +95,8 @@ func configureEvents() {
 	})
 
 	onMilestoneConfirmed = events.NewClosure(func(confirmation *whiteflag.Confirmation) {
-		// do not propagate during syncing, because it is not needed at all
-		if !tangle.IsNodeSyncedWithThreshold() {
+		// do not propagate during syncing, because it is not needed at all (unless tip selection during sync is allowed)
+		if !TipSelector.AllowUnsynced() && !tangle.IsNodeSyncedWithThreshold() {
 			return
 		}
 
@@ -0,0 +1,125 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// addressTransactionsRoute returns the hashes of the transactions of the given address in the order of the address index,
+// the zero value transactions first, followed by the value transactions, each ordered by transaction hash bytes.
+// the result is paginated, the "cursor" query parameter is the last transaction hash of the previous page.
+// since the order doesn't depend on the arrival of new transactions, paging through the results is deterministic.
+// every page only iterates the address index from the cursor on, so the cost of a page doesn't depend on the amount of transactions.
+// "value-only" only returns value transactions, "include-spent=false" omits the transactions spending from the address.
+func addressTransactionsRoute() {
+	mountRoute(http.MethodGet, "/addresses/:address/transactions", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["addresses"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [addresses] is protected"})
+				return
+			}
+		}
+
+		addressTrytes := c.Param("address")
+		if err := address.ValidAddress(addressTrytes); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid address: %s", addressTrytes)})
+			return
+		}
+		addressTrytes = addressTrytes[:consts.HashTrytesSize]
+
+		maxFindTransactions := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions)
+		limit := maxFindTransactions
+		if limitQuery := c.Query("limit"); limitQuery != "" {
+			var err error
+			if limit, err = strconv.Atoi(limitQuery); err != nil || limit <= 0 || limit > maxFindTransactions {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid limit: %s, max. %d", limitQuery, maxFindTransactions)})
+				return
+			}
+		}
+
+		valueOnly, err := parseBoolQuery(c, "value-only", false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: err.Error()})
+			return
+		}
+
+		includeSpent, err := parseBoolQuery(c, "include-spent", true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: err.Error()})
+			return
+		}
+
+		cursor := c.Query("cursor")
+		if cursor != "" && !guards.IsTransactionHash(cursor) {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid cursor: %s", cursor)})
+			return
+		}
+
+		var cursorHash hornet.Hash
+		if cursor != "" {
+			cursorHash = hornet.HashFromHashTrytes(cursor)
+		}
+
+		result := &AddressTransactionsReturn{
+			Address: addressTrytes,
+			Hashes:  []trinary.Hash{},
+		}
+
+		tangle.ForEachTransactionHashForAddress(hornet.HashFromAddressTrytes(addressTrytes), valueOnly, cursorHash, func(txHash hornet.Hash, isValue bool) bool {
+			// only value transactions are able to spend funds
+			if !includeSpent && isValue && isSpendingTransaction(txHash) {
+				return true
+			}
+
+			if len(result.Hashes) == limit {
+				// there are more transactions
+				result.Cursor = result.Hashes[len(result.Hashes)-1]
+				return false
+			}
+			result.Hashes = append(result.Hashes, txHash.Trytes())
+			return true
+		})
+		result.Count = len(result.Hashes)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// isSpendingTransaction returns whether the given transaction spends funds from its address.
+func isSpendingTransaction(txHash hornet.Hash) bool {
+	cachedTx := tangle.GetCachedTransactionOrNil(txHash) // tx +1
+	if cachedTx == nil {
+		return false
+	}
+	defer cachedTx.Release(true) // tx -1
+
+	return cachedTx.GetTransaction().Tx.Value < 0
+}
+
+// parseBoolQuery parses the given boolean query parameter, the default value is returned if the parameter is not set.
+func parseBoolQuery(c *gin.Context, name string, defaultValue bool) (bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %s", name, value)
+	}
+	return result, nil
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+
+	"github.com/gohornet/hornet/pkg/config"
 )
 
 var (
@@ -17,6 +19,27 @@ var (
 	ErrInternalError = errors.New("internal error")
 )
 
+const (
+	// ErrCodeNotFound is the error code returned if the requested route does not exist.
+	ErrCodeNotFound = "not_found"
+	// ErrCodeMethodNotAllowed is the error code returned if the requested route does not support the method.
+	ErrCodeMethodNotAllowed = "method_not_allowed"
+	// ErrCodeNotSupported is the error code returned if the requested legacy command is not supported by this node.
+	ErrCodeNotSupported = "not_supported"
+)
+
+var (
+	// unsupportedLegacyAPIcalls contains the IRI API calls which can not be mapped to the HORNET API.
+	//	getTips:                    the tip pool is managed by the tipselection plugin and not exposed anymore.
+	//	interruptAttachingToTangle: the PoW of attachToTangle is done synchronously and can't be interrupted.
+	//	getMissingTransactions:     missing transactions are requested internally by the solidifier.
+	unsupportedLegacyAPIcalls = map[string]struct{}{
+		"gettips":                    {},
+		"interruptattachingtotangle": {},
+		"getmissingtransactions":     {},
+	}
+)
+
 func networkWhitelisted(c *gin.Context) bool {
 	remoteHost, _, _ := net.SplitHostPort(c.Request.RemoteAddr)
 	remoteAddress := net.ParseIP(remoteHost)
@@ -45,10 +68,15 @@ func webAPIRoute() {
 			return
 		}
 		cmd := strings.ToLower(originCmd.(string))
+		c.Set(requestLogCommandKey, originCmd.(string))
 
 		// get the command and check if it's implemented
 		implementation, apiCallExists := implementedAPIcalls[cmd]
 		if !apiCallExists {
+			if _, isUnsupportedLegacyCall := unsupportedLegacyAPIcalls[cmd]; isUnsupportedLegacyCall && config.NodeConfig.GetBool(config.CfgWebAPILegacyCompatibility) {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("command [%v] is not supported by this node", originCmd), Code: ErrCodeNotSupported})
+				return
+			}
 			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("command [%v] is unknown", originCmd)})
 			return
 		}
@@ -56,6 +84,10 @@ func webAPIRoute() {
 		if !networkWhitelisted(c) {
 			// network is not whitelisted, check if the command is permitted, otherwise deny it.
 			if _, permitted := permittedEndpoints[cmd]; !permitted {
+				if cmd == "attachtotangle" {
+					c.JSON(http.StatusBadRequest, ErrorReturn{Error: "remote PoW is not available on this node: do the PoW locally and use broadcastTransactions, or use a node with remote PoW enabled", Code: ErrCodePoWNotAvailable})
+					return
+				}
 				c.JSON(http.StatusForbidden, ErrorReturn{Error: fmt.Sprintf("command [%v] is protected", originCmd)})
 				return
 			}
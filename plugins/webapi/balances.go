@@ -4,20 +4,29 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/trinary"
 
+	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 )
 
 func init() {
 	addEndpoint("getBalances", getBalances, implementedAPIcalls)
+	addEndpoint("getAddressBalances", getAddressBalances, implementedAPIcalls)
 }
 
+const (
+	// the amount of workers that read the balances of the addresses in parallel
+	addressBalancesWorkerCount = 4
+)
+
 func getBalances(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	e := ErrorReturn{}
 	query := &GetBalances{}
@@ -73,6 +82,10 @@ func getBalances(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 		// Address balance
 		result.Balances = append(result.Balances, strconv.FormatUint(balance, 10))
+
+		if query.IncludeConflicting {
+			result.DebugConflictingValues = append(result.DebugConflictingValues, strconv.FormatInt(getConfirmedConflictingValue(hornet.HashFromAddressTrytes(addr)), 10))
+		}
 	}
 
 	// The index of the milestone that confirmed the most recent balance
@@ -80,3 +93,104 @@ func getBalances(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	result.References = []string{cachedLatestSolidMs.GetBundle().GetMilestoneHash().Trytes()}
 	c.JSON(http.StatusOK, result)
 }
+
+// getConfirmedConflictingValue sums up the value of all confirmed but conflicting transactions of the given address.
+// conflicting transactions are never applied to the ledger, so this value is not part of the balance.
+func getConfirmedConflictingValue(addr hornet.Hash) int64 {
+
+	var value int64
+	for _, txHash := range tangle.GetTransactionHashesForAddress(addr, true, true, config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions)) {
+		cachedTx := tangle.GetCachedTransactionOrNil(txHash) // tx +1
+		if cachedTx == nil {
+			continue
+		}
+
+		if cachedTx.GetMetadata().IsConfirmed() && cachedTx.GetMetadata().IsConflicting() {
+			value += cachedTx.GetTransaction().Tx.Value
+		}
+		cachedTx.Release(true) // tx -1
+	}
+
+	return value
+}
+
+func getAddressBalances(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetAddressBalances{}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if len(query.Addresses) == 0 {
+		e.Error = "No addresses provided"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if len(query.Addresses) > maxRequestsList {
+		e.Error = "Too many addresses. Max. allowed: " + strconv.Itoa(maxRequestsList)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if !tangle.WaitForNodeSynced(waitForNodeSyncedTimeout) {
+		e.Error = ErrNodeNotSync.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetAddressBalancesReturn{
+		Balances: make(map[trinary.Hash]string),
+		Errors:   make(map[trinary.Hash]string),
+	}
+
+	// invalid addresses are reported per address and do not fail the whole request
+	var validAddresses []trinary.Hash
+	for _, addr := range query.Addresses {
+		if err := address.ValidAddress(addr); err != nil {
+			result.Errors[addr] = err.Error()
+			continue
+		}
+		validAddresses = append(validAddresses, addr)
+	}
+
+	tangle.ReadLockLedger()
+	defer tangle.ReadUnlockLedger()
+
+	var resultLock sync.Mutex
+	var wg sync.WaitGroup
+
+	addressesChan := make(chan trinary.Hash)
+	for worker := 0; worker < addressBalancesWorkerCount; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for addr := range addressesChan {
+				balance, _, err := tangle.GetBalanceForAddressWithoutLocking(hornet.HashFromAddressTrytes(addr))
+
+				resultLock.Lock()
+				if err != nil {
+					result.Errors[addr] = fmt.Sprintf("%v: %v", ErrInternalError, err)
+				} else {
+					result.Balances[addr] = strconv.FormatUint(balance, 10)
+				}
+				resultLock.Unlock()
+			}
+		}()
+	}
+
+	for _, addr := range validAddresses {
+		addressesChan <- addr
+	}
+	close(addressesChan)
+	wg.Wait()
+
+	result.MilestoneIndex = tangle.GetSolidMilestoneIndex()
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,142 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/math"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+const (
+	// ErrCodeValidationFailed is the error code returned if the submitted transactions have validation problems.
+	ErrCodeValidationFailed = "validation_failed"
+	// ErrCodeInvalidTrytes is the problem code of trytes which can't be parsed as a transaction.
+	ErrCodeInvalidTrytes = "invalid_trytes"
+	// ErrCodeInvalidAddress is the problem code of value transactions with an address which is not a valid KERL address.
+	ErrCodeInvalidAddress = "invalid_address"
+	// ErrCodeInvalidValue is the problem code of transactions with a value above the total supply.
+	ErrCodeInvalidValue = "invalid_value"
+	// ErrCodeInsufficientPoW is the problem code of transactions without a valid nonce on nodes which offer remote PoW.
+	ErrCodeInsufficientPoW = "insufficient_pow"
+	// ErrCodeInvalidAttachmentTimestamp is the problem code of transactions with an attachment timestamp outside the allowed skew.
+	ErrCodeInvalidAttachmentTimestamp = "invalid_attachment_timestamp"
+)
+
+// validationProblem is a problem found in the submitted transactions,
+// together with the response that is sent if the client requested to fail on the first problem.
+type validationProblem struct {
+	problem  *ValidationProblem
+	status   int
+	response interface{}
+}
+
+func newValidationProblem(status int, index *int, field string, code string, message string) *validationProblem {
+	return &validationProblem{
+		problem:  &ValidationProblem{Index: index, Field: field, Code: code, Message: message},
+		status:   status,
+		response: ErrorReturn{Error: message, Code: code},
+	}
+}
+
+// validateBroadcastTransactions parses the given transaction trytes and collects all problems which would lead to
+// a rejection of the transactions, instead of stopping at the first one.
+// the transactions are only returned if no problems were found.
+func validateBroadcastTransactions(query *BroadcastTransactions, remotePoW bool) (transaction.Transactions, []*validationProblem) {
+	maxTimestampSkew := time.Duration(config.NodeConfig.GetInt(config.CfgWebAPIMaxAttachmentTimestampSkewSeconds)) * time.Second
+	maxDataTrytes := config.NodeConfig.GetInt(config.CfgWebAPIMaxDataTrytesPerBundle)
+	mwm := config.NodeConfig.GetUint64(config.CfgCoordinatorMWM)
+
+	var problems []*validationProblem
+
+	txs := make(transaction.Transactions, 0, len(query.Trytes))
+	for i, trytes := range query.Trytes {
+		index := i
+
+		if err := trinary.ValidTrytes(trytes); err != nil {
+			problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "trytes", ErrCodeInvalidTrytes, fmt.Sprintf("transaction %d: %v", index, err)))
+			continue
+		}
+
+		parsedTxs, err := transaction.AsTransactionObjects([]trinary.Trytes{trytes}, nil)
+		if err != nil {
+			problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "trytes", ErrCodeInvalidTrytes, fmt.Sprintf("transaction %d: %v", index, err)))
+			continue
+		}
+		tx := &parsedTxs[0]
+
+		// the expected hashes are checked before anything is stored, so serialization bugs of the client are detected early
+		if len(query.ExpectedHashes) > 0 && query.ExpectedHashes[index] != tx.Hash {
+			problem := newValidationProblem(http.StatusConflict, &index, "expectedHashes", ErrCodeHashMismatch, fmt.Sprintf("hash of transaction %d doesn't match the expected hash", index))
+			problem.response = HashMismatchReturn{
+				Error:        problem.problem.Message,
+				Code:         ErrCodeHashMismatch,
+				Index:        index,
+				ExpectedHash: query.ExpectedHashes[index],
+				ComputedHash: tx.Hash,
+			}
+			problems = append(problems, problem)
+		}
+
+		if tx.Value != 0 {
+			// last trit must be zero because of KERL
+			if addressTrits := trinary.MustTrytesToTrits(tx.Address); addressTrits[consts.AddressTrinarySize-1] != 0 {
+				problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "address", ErrCodeInvalidAddress, fmt.Sprintf("transaction %d: %v", index, consts.ErrInvalidAddress)))
+			}
+
+			if math.AbsInt64(tx.Value) > consts.TotalSupply {
+				problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "value", ErrCodeInvalidValue, fmt.Sprintf("transaction %d: value %d exceeds the total supply", index, tx.Value)))
+			}
+		}
+
+		if !transaction.HasValidNonce(tx, mwm) {
+			if remotePoW {
+				problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "nonce", ErrCodeInsufficientPoW, fmt.Sprintf("transaction %d has no valid nonce for MWM %d", index, mwm)))
+			} else {
+				// clients which can't use attachToTangle on this node get a specific error if they forgot to do the PoW
+				problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "nonce", ErrCodePoWNotAvailable, fmt.Sprintf("transaction %d has no valid nonce for MWM %d and this node does not perform the PoW: supply transactions with a valid nonce or use a node with remote PoW enabled", index, mwm)))
+			}
+		}
+
+		if maxTimestampSkew > 0 {
+			if err := checkAttachmentTimestamps(parsedTxs, maxTimestampSkew); err != nil {
+				problems = append(problems, newValidationProblem(http.StatusBadRequest, &index, "attachmentTimestamp", ErrCodeInvalidAttachmentTimestamp, err.Error()))
+			}
+		}
+
+		txs = append(txs, *tx)
+	}
+
+	if len(txs) != len(query.Trytes) {
+		// the checks of the whole request need all transactions
+		return nil, problems
+	}
+
+	if maxDataTrytes > 0 {
+		if err := checkDataSize(txs, maxDataTrytes); err != nil {
+			problems = append(problems, newValidationProblem(http.StatusRequestEntityTooLarge, nil, "signatureMessageFragment", ErrCodeDataTooLarge, err.Error()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	return txs, nil
+}
+
+// reserveBroadcastTagQuotas reserves the tag quotas for the validated transactions.
+// it is called right before the transactions are handed over to the node, so requests which are rejected
+// by earlier checks don't hold any reservations.
+func reserveBroadcastTagQuotas(txs transaction.Transactions) (tagQuotaReservation, *validationProblem) {
+	reservation, err := reserveTagQuotas(txs)
+	if err != nil {
+		return nil, newValidationProblem(http.StatusForbidden, nil, "tag", ErrCodeTagQuotaExceeded, err.Error())
+	}
+	return reservation, nil
+}
@@ -0,0 +1,93 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/guards"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("getBundleInputs", getBundleInputs, implementedAPIcalls)
+}
+
+// getBundleInputs returns the inputs of the value bundle of the given tail transaction,
+// i.e. the addresses the bundle spends from and the spent values.
+// if the bundle is conflicting, the funds of the inputs may have been spent by another bundle.
+func getBundleInputs(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetBundleInputs{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	cachedBndl := tangle.GetCachedBundleOrNil(hornet.HashFromHashTrytes(query.TxHash)) // bundle +1
+	if cachedBndl == nil {
+		e.Error = "Bundle not found, the transaction is unknown or not a tail transaction"
+		e.Code = ErrCodeNotFound
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+	defer cachedBndl.Release(true) // bundle -1
+
+	bndl := cachedBndl.GetBundle()
+	if !bndl.IsValid() {
+		e.Error = "Bundle is invalid"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetBundleInputsReturn{
+		BundleHash:  bndl.GetBundleHash().Trytes(),
+		Inputs:      []*BundleInput{},
+		Conflicting: bndl.IsConflicting(),
+	}
+
+	cachedTailTxMeta := bndl.GetTailMetadata() // meta +1
+	result.Confirmed, result.MilestoneIndex = cachedTailTxMeta.GetMetadata().GetConfirmed()
+	cachedTailTxMeta.Release(true) // meta -1
+
+	cachedTxs := bndl.GetTransactions() // tx +1
+	for _, cachedTx := range cachedTxs {
+		tx := cachedTx.GetTransaction()
+		if tx.Tx.Value >= 0 {
+			continue
+		}
+
+		result.Inputs = append(result.Inputs, &BundleInput{
+			TxHash:       tx.GetTxHash().Trytes(),
+			CurrentIndex: tx.Tx.CurrentIndex,
+			Address:      tx.Tx.Address,
+			Value:        -tx.Tx.Value,
+		})
+	}
+	cachedTxs.Release(true) // tx -1
+
+	if len(result.Inputs) == 0 {
+		e.Error = "Bundle does not spend any funds"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	sort.Slice(result.Inputs, func(i, j int) bool {
+		return result.Inputs[i].CurrentIndex < result.Inputs[j].CurrentIndex
+	})
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,98 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/guards"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("getBundleOutputs", getBundleOutputs, implementedAPIcalls)
+}
+
+// getBundleOutputs returns the outputs of the value bundle of the given tail transaction,
+// i.e. the addresses the bundle transfers funds to and the received values.
+// an output is marked as spent if its address was spent from, which is only known if spent addresses are enabled.
+func getBundleOutputs(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetBundleOutputs{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	cachedBndl := tangle.GetCachedBundleOrNil(hornet.HashFromHashTrytes(query.TxHash)) // bundle +1
+	if cachedBndl == nil {
+		e.Error = "Bundle not found, the transaction is unknown, pruned or not a tail transaction"
+		e.Code = ErrCodeNotFound
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+	defer cachedBndl.Release(true) // bundle -1
+
+	bndl := cachedBndl.GetBundle()
+	if !bndl.IsValid() {
+		e.Error = "Bundle is invalid"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetBundleOutputsReturn{
+		BundleHash:            bndl.GetBundleHash().Trytes(),
+		Outputs:               []*BundleOutput{},
+		Conflicting:           bndl.IsConflicting(),
+		SpentAddressesEnabled: tangle.GetSnapshotInfo().IsSpentAddressesEnabled(),
+	}
+
+	cachedTailTxMeta := bndl.GetTailMetadata() // meta +1
+	result.Confirmed, result.MilestoneIndex = cachedTailTxMeta.GetMetadata().GetConfirmed()
+	cachedTailTxMeta.Release(true) // meta -1
+
+	cachedTxs := bndl.GetTransactions() // tx +1
+	for _, cachedTx := range cachedTxs {
+		tx := cachedTx.GetTransaction()
+		if tx.Tx.Value <= 0 {
+			continue
+		}
+
+		output := &BundleOutput{
+			TxHash:       tx.GetTxHash().Trytes(),
+			CurrentIndex: tx.Tx.CurrentIndex,
+			Address:      tx.Tx.Address,
+			Value:        tx.Tx.Value,
+		}
+		if result.SpentAddressesEnabled {
+			output.Spent = tangle.WasAddressSpentFrom(tx.GetAddress())
+		}
+		result.Outputs = append(result.Outputs, output)
+	}
+	cachedTxs.Release(true) // tx -1
+
+	if len(result.Outputs) == 0 {
+		e.Error = "Bundle does not transfer any funds"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	sort.Slice(result.Outputs, func(i, j int) bool {
+		return result.Outputs[i].CurrentIndex < result.Outputs[j].CurrentIndex
+	})
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,114 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("getConfirmedTransactionCounts", getConfirmedTransactionCounts, implementedAPIcalls)
+}
+
+// getConfirmedTransactionCounts returns the amount of transactions and bundles confirmed by every milestone in the given range.
+// the cone of every milestone has to be traversed, so the range is capped by "httpAPI.limits.maxRequestsList".
+func getConfirmedTransactionCounts(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetConfirmedTransactionCounts{}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	smi := tangle.GetSolidMilestoneIndex()
+	if query.EndIndex == 0 {
+		query.EndIndex = smi
+	}
+
+	if err := validateMilestoneRange(query.StartIndex, query.EndIndex, maxRequestsList); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetConfirmedTransactionCountsReturn{Counts: []*ConfirmedTransactionCount{}}
+	for msIndex := query.StartIndex; msIndex <= query.EndIndex; msIndex++ {
+		count, err := countConfirmedTransactions(msIndex, abortSignal)
+		if err != nil {
+			if errors.Is(err, tangle.ErrOperationAborted) {
+				e.Error = err.Error()
+				c.JSON(http.StatusServiceUnavailable, e)
+				return
+			}
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+		result.Counts = append(result.Counts, count)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// countConfirmedTransactions walks the part of the cone of the given milestone which was confirmed by that milestone.
+func countConfirmedTransactions(msIndex milestone.Index, abortSignal <-chan struct{}) (*ConfirmedTransactionCount, error) {
+
+	cachedMs := tangle.GetCachedMilestoneOrNil(msIndex) // milestone +1
+	if cachedMs == nil {
+		return nil, fmt.Errorf("milestone %d not found", msIndex)
+	}
+	msHash := cachedMs.GetMilestone().Hash
+	cachedMs.Release(true) // milestone -1
+
+	count := &ConfirmedTransactionCount{MilestoneIndex: msIndex}
+
+	err := dag.TraverseApprovees(msHash,
+		// traversal stops if no more transactions pass the given condition
+		func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed()
+			return confirmed && at == msIndex, nil
+		},
+		// consumer
+		func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			meta := cachedTxMeta.GetMetadata()
+
+			count.Transactions++
+			if meta.IsTail() {
+				count.Bundles++
+			}
+			if meta.IsConflicting() {
+				count.Conflicting++
+			}
+			return nil
+		},
+		// called on missing approvees
+		func(approveeHash hornet.Hash) error {
+			return fmt.Errorf("%w: transaction %s", tangle.ErrTransactionNotFound, approveeHash.Trytes())
+		},
+		// called on solid entry points
+		nil,
+		false,
+		false,
+		abortSignal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return count, nil
+}
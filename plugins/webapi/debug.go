@@ -11,7 +11,9 @@ import (
 
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
 
+	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/dag"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/tangle"
@@ -24,7 +26,13 @@ func init() {
 	addEndpoint("searchConfirmedApprover", searchConfirmedApprover, implementedAPIcalls)
 	addEndpoint("searchEntryPoints", searchEntryPoints, implementedAPIcalls)
 	addEndpoint("triggerSolidifier", triggerSolidifier, implementedAPIcalls)
+	addEndpoint("solidifySubtangle", solidifySubtangle, implementedAPIcalls)
 	addEndpoint("getFundsOnSpentAddresses", getFundsOnSpentAddresses, implementedAPIcalls)
+	addEndpoint("getDatabaseStats", getDatabaseStats, implementedAPIcalls)
+	addEndpoint("getDatabaseVersion", getDatabaseVersion, implementedAPIcalls)
+	addEndpoint("clearTransactionFilter", clearTransactionFilter, implementedAPIcalls)
+	addEndpoint("getTransactionArrivalSource", getTransactionArrivalSource, implementedAPIcalls)
+	addEndpoint("reprocessTransaction", reprocessTransaction, implementedAPIcalls)
 }
 
 func getRequests(_ interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -199,7 +207,7 @@ func searchConfirmedApprover(i interface{}, c *gin.Context, _ <-chan struct{}) {
 func searchEntryPoints(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	e := ErrorReturn{}
 	query := &SearchEntryPoint{}
-	result := &SearchEntryPointReturn{}
+	result := &SearchEntryPointReturn{TanglePath: []*TransactionWithApprovers{}, EntryPoints: []*EntryPoint{}}
 
 	if err := mapstructure.Decode(i, query); err != nil {
 		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
@@ -275,9 +283,47 @@ func triggerSolidifier(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	c.Status(http.StatusAccepted)
 }
 
+func solidifySubtangle(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &SolidifySubtangle{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	txHash := hornet.HashFromHashTrytes(query.TxHash)
+	if !tangle.ContainsTransaction(txHash) {
+		e.Error = "Transaction not found"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	missingTxHashes, err := tanglePlugin.SolidifySubtangle(txHash, abortSignal)
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	missingRequested := []trinary.Hash{}
+	for _, missingTxHash := range missingTxHashes {
+		missingRequested = append(missingRequested, missingTxHash.Trytes())
+	}
+
+	c.JSON(http.StatusOK, SolidifySubtangleReturn{Solid: len(missingRequested) == 0, MissingRequested: missingRequested})
+}
+
 func getFundsOnSpentAddresses(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	e := ErrorReturn{}
-	result := &GetFundsOnSpentAddressesReturn{}
+	result := &GetFundsOnSpentAddressesReturn{Addresses: []*AddressWithBalance{}}
 
 	if !tangle.GetSnapshotInfo().IsSpentAddressesEnabled() {
 		e.Error = "getFundsOnSpentAddresses not available in this node"
@@ -300,3 +346,137 @@ func getFundsOnSpentAddresses(i interface{}, c *gin.Context, _ <-chan struct{})
 
 	c.JSON(http.StatusOK, result)
 }
+
+func getDatabaseStats(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	dbStats, err := tangle.GetDatabaseStats()
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	result := GetDatabaseStatsReturn{Databases: []*DatabaseStats{}}
+	for _, db := range dbStats {
+		buckets := []*DatabaseBucketStats{}
+		for _, bucket := range db.Buckets {
+			buckets = append(buckets, &DatabaseBucketStats{
+				Name:           bucket.Name,
+				KeyCount:       bucket.KeyCount,
+				InUseBytes:     bucket.InUseBytes,
+				AllocatedBytes: bucket.AllocatedBytes,
+			})
+		}
+
+		result.Databases = append(result.Databases, &DatabaseStats{
+			Name:             db.Name,
+			Size:             db.Size,
+			FreePageCount:    db.FreePageCount,
+			PendingPageCount: db.PendingPageCount,
+			FreeAllocBytes:   db.FreeAllocBytes,
+			OpenReadTxCount:  db.OpenReadTxCount,
+			Buckets:          buckets,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getDatabaseVersion returns the schema version of the database on disk and the version expected by this node.
+// the node only starts after a pending migration finished, so both versions are always equal while the API is served.
+func getDatabaseVersion(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	c.JSON(http.StatusOK, GetDatabaseVersionReturn{
+		Version:         tangle.GetDatabaseVersion(),
+		ExpectedVersion: tangle.DbVersion,
+	})
+}
+
+// clearTransactionFilter clears the filter of already seen incoming transaction data,
+// so that transactions which were received before are processed again.
+// transactions which are already stored in the database are still treated as known.
+func clearTransactionFilter(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	if !config.NodeConfig.GetBool(config.CfgWebAPIDebugAllowClearTransactionFilter) {
+		e.Error = fmt.Sprintf("clearing the transaction filter is disabled, enable it via \"%s\"", config.CfgWebAPIDebugAllowClearTransactionFilter)
+		c.JSON(http.StatusForbidden, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, ClearTransactionFilterReturn{Cleared: gossip.Processor().ClearWorkUnits()})
+}
+
+// getTransactionArrivalSource returns the ID of the neighbor which delivered the given transaction first,
+// "local" if the transaction was not received via gossip, or "unknown" if it is not within the tracked window.
+func getTransactionArrivalSource(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetTransactionArrivalSource{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !tanglePlugin.IsArrivalSourceTrackingEnabled() {
+		e.Error = fmt.Sprintf("tracking the arrival sources is disabled, enable it via \"%s\"", config.CfgTangleArrivalSourcesWindow)
+		c.JSON(http.StatusForbidden, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, GetTransactionArrivalSourceReturn{
+		TxHash: query.TxHash,
+		Source: tanglePlugin.GetArrivalSource(hornet.HashFromHashTrytes(query.TxHash)),
+	})
+}
+
+// reprocessTransaction runs a stored transaction through the checks of the transaction processor again,
+// as if it was newly received via gossip, to validate changes of the processing logic against real transactions.
+// the transaction is neither stored again nor broadcasted.
+func reprocessTransaction(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &ReprocessTransaction{}
+
+	if !config.NodeConfig.GetBool(config.CfgWebAPIDebugAllowReprocessTransaction) {
+		e.Error = fmt.Sprintf("reprocessing transactions is disabled, enable it via \"%s\"", config.CfgWebAPIDebugAllowReprocessTransaction)
+		c.JSON(http.StatusForbidden, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	cachedTx := tangle.GetCachedTransactionOrNil(hornet.HashFromHashTrytes(query.TxHash)) // tx +1
+	if cachedTx == nil {
+		e.Error = fmt.Sprintf("Transaction not found: %s", query.TxHash)
+		e.Code = ErrCodeNotFound
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+	txBytes := cachedTx.GetTransaction().RawBytes
+	cachedTx.Release(true) // tx -1
+
+	if err := gossip.Processor().Reprocess(txBytes); err != nil {
+		c.JSON(http.StatusOK, ReprocessTransactionReturn{Outcome: "dropped", Reason: err.Error()})
+		return
+	}
+
+	// the transaction is already stored, so it would not be stored again
+	c.JSON(http.StatusOK, ReprocessTransactionReturn{Outcome: "duplicate"})
+}
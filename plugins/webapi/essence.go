@@ -0,0 +1,116 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/signing"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+)
+
+func init() {
+	addEndpoint("getBundleEssence", getBundleEssence, implementedAPIcalls)
+}
+
+// bundleEssence returns the part of the transaction which is absorbed to calculate the bundle hash.
+func bundleEssence(tx *transaction.Transaction) trinary.Trytes {
+	essenceTrits := trinary.MustTrytesToTrits(tx.Address[:consts.HashTrytesSize])
+	essenceTrits = append(essenceTrits, trinary.MustPadTrits(trinary.IntToTrits(tx.Value), consts.ValueSizeTrinary)...)
+	essenceTrits = append(essenceTrits, trinary.MustPadTrits(trinary.MustTrytesToTrits(tx.ObsoleteTag), consts.ObsoleteTagTrinarySize)...)
+	essenceTrits = append(essenceTrits, trinary.MustPadTrits(trinary.IntToTrits(int64(tx.Timestamp)), consts.TimestampTrinarySize)...)
+	essenceTrits = append(essenceTrits, trinary.MustPadTrits(trinary.IntToTrits(int64(tx.CurrentIndex)), consts.CurrentIndexTrinarySize)...)
+	essenceTrits = append(essenceTrits, trinary.MustPadTrits(trinary.IntToTrits(int64(tx.LastIndex)), consts.LastIndexTrinarySize)...)
+	return trinary.MustTritsToTrytes(essenceTrits)
+}
+
+func getBundleEssence(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetBundleEssence{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	// Reject empty requests
+	if len(query.Trytes) == 0 {
+		e.Error = "No trytes given."
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	for _, trytes := range query.Trytes {
+		if err := trinary.ValidTrytes(trytes); err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+	}
+
+	txs, err := transaction.AsTransactionObjects(query.Trytes, nil)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	// Reject bundles with invalid tx amount
+	if uint64(len(txs)) != txs[0].LastIndex+1 {
+		e.Error = fmt.Sprintf("Invalid bundle length. Received txs: %v, Bundle requires: %v", len(txs), txs[0].LastIndex+1)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	// Sort transactions (lowest to highest index)
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].CurrentIndex < txs[j].CurrentIndex
+	})
+
+	// Check transaction indexes
+	for i := range txs {
+		if txs[i].CurrentIndex != uint64(i) || txs[i].LastIndex != txs[0].LastIndex {
+			e.Error = fmt.Sprintf("Invalid transaction index. Got: %d, expected: %d", txs[i].CurrentIndex, i)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+	}
+
+	// Check the sum of the values
+	var sum int64
+	for i := range txs {
+		sum += txs[i].Value
+	}
+	if sum != 0 {
+		e.Error = fmt.Sprintf("Invalid bundle value. Sum of values: %d", sum)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	// finalize the bundle the same way the node validates it (the transactions are neither stored nor broadcasted)
+	bndl, err := bundle.Finalize(txs)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetBundleEssenceReturn{
+		BundleHash:           bndl[0].Bundle,
+		NormalizedBundleHash: signing.NormalizedBundleHash(bndl[0].Bundle),
+		ObsoleteTag:          bndl[0].ObsoleteTag,
+		Essences:             []trinary.Trytes{},
+	}
+	for i := range bndl {
+		result.Essences = append(result.Essences, bundleEssence(&bndl[i]))
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -2,15 +2,82 @@ package webapi
 
 import (
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	tanglemodel "github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/plugins/peering"
 	"github.com/gohornet/hornet/plugins/tangle"
 )
 
+const (
+	// databaseProbeInterval is the minimum interval between two write probes of the database health check,
+	// so frequent health checks don't write to the database on every request.
+	databaseProbeInterval = 1 * time.Minute
+)
+
+var (
+	// healthChecks are the checks which can be enabled for the health check route by their name.
+	healthChecks = map[string]func() bool{
+		"synced": func() bool {
+			return tanglemodel.IsNodeSyncedWithThreshold()
+		},
+		"peers": func() bool {
+			return peering.Manager().ConnectedPeerCount() >= config.NodeConfig.GetInt(config.CfgWebAPIHealthzMinConnectedPeers)
+		},
+		"milestoneAge": func() bool {
+			return tangle.IsLatestMilestoneRecent()
+		},
+		"database": databaseWritable,
+		"solidificationBacklog": func() bool {
+			return tangle.GetMilestonesToSolidifyCount() <= milestone.Index(config.NodeConfig.GetInt(config.CfgWebAPIHealthzMaxMilestonesToSolidify))
+		},
+	}
+
+	// enabledHealthChecks are the names of the checks which have to pass for the node to be healthy.
+	enabledHealthChecks []string
+
+	// the result of the last database write probe.
+	databaseProbeLock   sync.Mutex
+	databaseProbeTime   time.Time
+	databaseProbeResult bool
+)
+
+// databaseWritable returns whether the database accepted the last write probe.
+// the database is probed again if the last probe is older than databaseProbeInterval.
+func databaseWritable() bool {
+	databaseProbeLock.Lock()
+	defer databaseProbeLock.Unlock()
+
+	if !databaseProbeTime.IsZero() && time.Since(databaseProbeTime) < databaseProbeInterval {
+		return databaseProbeResult
+	}
+
+	databaseProbeResult = tanglemodel.CheckDatabaseWritable() == nil
+	databaseProbeTime = time.Now()
+
+	return databaseProbeResult
+}
+
+// configureHealthChecks loads the configured health checks.
+func configureHealthChecks() {
+	for _, name := range config.NodeConfig.GetStringSlice(config.CfgWebAPIHealthzChecks) {
+		if _, exists := healthChecks[name]; !exists {
+			log.Warnf("Unknown health check: %s", name)
+			continue
+		}
+		enabledHealthChecks = append(enabledHealthChecks, name)
+	}
+}
+
+// healthzRoute reports whether the node is healthy, e.g. for the readiness checks of load balancers.
+// the node is healthy if all configured health checks pass, otherwise the failed checks are returned.
 func healthzRoute() {
-	api.GET("/healthz", func(c *gin.Context) {
+	mountRoute(http.MethodGet, "/healthz", func(c *gin.Context) {
 
 		if !networkWhitelisted(c) {
 			// network is not whitelisted, check if the route is permitted, otherwise deny it.
@@ -27,8 +94,15 @@ func healthzRoute() {
 		}
 
 		// node mode
-		if !tangle.IsNodeHealthy() {
-			c.Status(http.StatusServiceUnavailable)
+		failedChecks := []string{}
+		for _, name := range enabledHealthChecks {
+			if !healthChecks[name]() {
+				failedChecks = append(failedChecks, name)
+			}
+		}
+
+		if len(failedChecks) > 0 {
+			c.JSON(http.StatusServiceUnavailable, HealthzReturn{FailedChecks: failedChecks})
 			return
 		}
 
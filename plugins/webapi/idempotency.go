@@ -0,0 +1,187 @@
+package webapi
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+const (
+	// IdempotencyKeyHeader is the header which contains the optional idempotency key of a request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// ErrCodeIdempotencyKeyConflict is the error code returned if an idempotency key was reused for a different request.
+	ErrCodeIdempotencyKeyConflict = "idempotency_key_conflict"
+	// ErrCodeIdempotencyKeyInFlight is the error code returned if a request with the same idempotency key is still processed.
+	ErrCodeIdempotencyKeyInFlight = "idempotency_key_in_flight"
+)
+
+type idempotencyEntry struct {
+	key           string
+	requestDigest [sha256.Size]byte
+	// response is nil as long as the request is in flight.
+	response interface{}
+	expires  time.Time
+}
+
+// idempotencyCache holds the responses of requests with an idempotency key.
+// the entries are kept in the order of their expiry, so expired and surplus entries are removed from the front.
+type idempotencyCache struct {
+	sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newIdempotencyCache() *idempotencyCache {
+	return &idempotencyCache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+var (
+	idempotencyEntries = newIdempotencyCache()
+)
+
+// removeWithoutLocking removes the given element from the cache.
+func (ic *idempotencyCache) removeWithoutLocking(elem *list.Element) {
+	delete(ic.entries, elem.Value.(*idempotencyEntry).key)
+	ic.order.Remove(elem)
+}
+
+// lookupOrReserve returns a copy of the entry of the given key.
+// if no valid entry exists, the key is reserved for the caller and false is returned.
+// if the cache already holds "maxEntries" entries, the oldest ones are removed.
+func (ic *idempotencyCache) lookupOrReserve(key string, requestDigest [sha256.Size]byte, ttl time.Duration, maxEntries int, now time.Time) (idempotencyEntry, bool) {
+	ic.Lock()
+	defer ic.Unlock()
+
+	// remove expired entries
+	for elem := ic.order.Front(); elem != nil && now.After(elem.Value.(*idempotencyEntry).expires); elem = ic.order.Front() {
+		ic.removeWithoutLocking(elem)
+	}
+
+	if elem, exists := ic.entries[key]; exists {
+		return *elem.Value.(*idempotencyEntry), true
+	}
+
+	for maxEntries > 0 && ic.order.Len() >= maxEntries {
+		ic.removeWithoutLocking(ic.order.Front())
+	}
+
+	ic.entries[key] = ic.order.PushBack(&idempotencyEntry{
+		key:           key,
+		requestDigest: requestDigest,
+		expires:       now.Add(ttl),
+	})
+
+	return idempotencyEntry{}, false
+}
+
+// store sets the response of the reserved key.
+// the response is dropped if the reservation was removed in the meantime.
+func (ic *idempotencyCache) store(key string, requestDigest [sha256.Size]byte, response interface{}, ttl time.Duration, now time.Time) {
+	ic.Lock()
+	defer ic.Unlock()
+
+	elem, exists := ic.entries[key]
+	if !exists {
+		return
+	}
+
+	entry := elem.Value.(*idempotencyEntry)
+	if entry.response != nil || entry.requestDigest != requestDigest {
+		return
+	}
+
+	entry.response = response
+	entry.expires = now.Add(ttl)
+	ic.order.MoveToBack(elem)
+}
+
+// release removes the reservation of the given key if no response was stored.
+func (ic *idempotencyCache) release(key string) {
+	ic.Lock()
+	defer ic.Unlock()
+
+	if elem, exists := ic.entries[key]; exists && elem.Value.(*idempotencyEntry).response == nil {
+		ic.removeWithoutLocking(elem)
+	}
+}
+
+// idempotencyDigest returns the digest of the given request parts, which is used to detect reused idempotency keys.
+func idempotencyDigest(parts ...string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(strings.Join(parts, ",")))
+}
+
+// idempotencyKey returns the key of the request for the given command.
+// an empty key is returned if the client didn't send an idempotency key or the feature is disabled.
+func idempotencyKey(c *gin.Context, cmd string) string {
+	if config.NodeConfig.GetInt(config.CfgWebAPIIdempotencyKeyTTLSeconds) <= 0 {
+		return ""
+	}
+
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		return ""
+	}
+
+	return cmd + ":" + key
+}
+
+// replayIdempotentResponse answers the request with the cached response of a former request with the same idempotency key.
+// if the key was used for a different request or the former request is still in flight, the request is rejected.
+// returns false if there is no cached response and the request has to be processed.
+// in that case the key is reserved until the response is stored or the key is released via releaseIdempotencyKey.
+func replayIdempotentResponse(c *gin.Context, key string, requestDigest [sha256.Size]byte) bool {
+	if key == "" {
+		return false
+	}
+
+	ttl := time.Duration(config.NodeConfig.GetInt(config.CfgWebAPIIdempotencyKeyTTLSeconds)) * time.Second
+	maxEntries := config.NodeConfig.GetInt(config.CfgWebAPIIdempotencyMaxKeys)
+
+	entry, exists := idempotencyEntries.lookupOrReserve(key, requestDigest, ttl, maxEntries, time.Now())
+	if !exists {
+		return false
+	}
+
+	if entry.requestDigest != requestDigest {
+		c.JSON(http.StatusConflict, ErrorReturn{Error: "idempotency key was already used for a different request", Code: ErrCodeIdempotencyKeyConflict})
+		return true
+	}
+
+	if entry.response == nil {
+		c.JSON(http.StatusConflict, ErrorReturn{Error: "a request with the same idempotency key is still in progress", Code: ErrCodeIdempotencyKeyInFlight})
+		return true
+	}
+
+	c.JSON(http.StatusOK, entry.response)
+	return true
+}
+
+// storeIdempotentResponse caches the response of a successful request for the configured TTL.
+func storeIdempotentResponse(key string, requestDigest [sha256.Size]byte, response interface{}) {
+	if key == "" {
+		return
+	}
+
+	ttl := time.Duration(config.NodeConfig.GetInt(config.CfgWebAPIIdempotencyKeyTTLSeconds)) * time.Second
+	idempotencyEntries.store(key, requestDigest, response, ttl, time.Now())
+}
+
+// releaseIdempotencyKey releases the reservation of the key if the request failed,
+// so the client is able to retry it with the same key.
+func releaseIdempotencyKey(key string) {
+	if key == "" {
+		return
+	}
+
+	idempotencyEntries.release(key)
+}
@@ -0,0 +1,67 @@
+package webapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	cache := newIdempotencyCache()
+	now := time.Now()
+	ttl := time.Minute
+	digestA := idempotencyDigest("A")
+	digestB := idempotencyDigest("B")
+
+	// the first request reserves the key
+	_, exists := cache.lookupOrReserve("key", digestA, ttl, 10, now)
+	assert.False(t, exists)
+
+	// a concurrent request sees the reservation without a response
+	entry, exists := cache.lookupOrReserve("key", digestA, ttl, 10, now)
+	assert.True(t, exists)
+	assert.Nil(t, entry.response)
+
+	// the stored response is replayed
+	cache.store("key", digestA, "response", ttl, now)
+	entry, exists = cache.lookupOrReserve("key", digestA, ttl, 10, now)
+	assert.True(t, exists)
+	assert.Equal(t, "response", entry.response)
+
+	// a different request with the same key is detected by the digest
+	entry, exists = cache.lookupOrReserve("key", digestB, ttl, 10, now)
+	assert.True(t, exists)
+	assert.NotEqual(t, digestB, entry.requestDigest)
+
+	// releasing a key with a stored response has no effect
+	cache.release("key")
+	_, exists = cache.lookupOrReserve("key", digestA, ttl, 10, now)
+	assert.True(t, exists)
+
+	// the entry expires after the TTL
+	_, exists = cache.lookupOrReserve("key", digestA, ttl, 10, now.Add(2*ttl))
+	assert.False(t, exists)
+
+	// a released reservation can be reserved again
+	cache.release("key")
+	_, exists = cache.lookupOrReserve("key", digestA, ttl, 10, now)
+	assert.False(t, exists)
+}
+
+func TestIdempotencyCacheMaxEntries(t *testing.T) {
+	cache := newIdempotencyCache()
+	now := time.Now()
+	digest := idempotencyDigest("A")
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, exists := cache.lookupOrReserve(key, digest, time.Minute, 2, now)
+		assert.False(t, exists)
+	}
+
+	// the oldest key was removed to respect the limit
+	assert.Len(t, cache.entries, 2)
+	assert.NotContains(t, cache.entries, "a")
+	assert.Contains(t, cache.entries, "b")
+	assert.Contains(t, cache.entries, "c")
+}
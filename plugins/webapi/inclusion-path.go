@@ -0,0 +1,131 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("getInclusionPath", getInclusionPath, implementedAPIcalls)
+}
+
+func getInclusionPath(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetInclusionPath{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	txHash := hornet.HashFromHashTrytes(query.TxHash)
+
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
+	if cachedTxMeta == nil {
+		e.Error = "Transaction not found"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+	confirmed, msIndex := cachedTxMeta.GetMetadata().GetConfirmed()
+	cachedTxMeta.Release(true) // meta -1
+
+	if !confirmed {
+		e.Error = "Transaction not confirmed yet"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	path, err := searchInclusionPath(txHash, msIndex, config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxInclusionPathTraversal), abortSignal)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, GetInclusionPathReturn{Path: path, MilestoneIndex: msIndex})
+}
+
+// searchInclusionPath walks the cone of the milestone with the given index until the given transaction is found
+// and returns the path from the transaction up to the tail transaction of the milestone.
+func searchInclusionPath(txHash hornet.Hash, msIndex milestone.Index, maxTraversal int, abortSignal <-chan struct{}) ([]trinary.Hash, error) {
+
+	cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+	if cachedMs == nil {
+		return nil, fmt.Errorf("milestone %d not found", msIndex)
+	}
+	msTailHash := cachedMs.GetBundle().GetTailHash()
+	cachedMs.Release(true) // bundle -1
+
+	// approvers holds the approver of every traversed transaction, which is used to reconstruct the path
+	approvers := map[string]hornet.Hash{string(msTailHash): nil}
+	txsToTraverse := hornet.Hashes{msTailHash}
+
+	for traversed := 0; len(txsToTraverse) != 0; traversed++ {
+		select {
+		case <-abortSignal:
+			return nil, tangle.ErrOperationAborted
+		default:
+		}
+
+		if traversed >= maxTraversal {
+			return nil, fmt.Errorf("inclusion path not found within %d transactions", maxTraversal)
+		}
+
+		currentTxHash := txsToTraverse[0]
+		txsToTraverse = txsToTraverse[1:]
+
+		if string(currentTxHash) == string(txHash) {
+			var path []trinary.Hash
+			for hash := currentTxHash; hash != nil; hash = approvers[string(hash)] {
+				path = append(path, hash.Trytes())
+			}
+			return path, nil
+		}
+
+		if tangle.SolidEntryPointsContain(currentTxHash) {
+			// do not traverse below the solid entry points
+			continue
+		}
+
+		cachedTxMeta := tangle.GetCachedTxMetadataOrNil(currentTxHash) // meta +1
+		if cachedTxMeta == nil {
+			return nil, fmt.Errorf("transaction not found: %v", currentTxHash.Trytes())
+		}
+
+		if confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed(); !confirmed || at != msIndex {
+			// only walk the cone of the milestone which confirmed the transaction
+			cachedTxMeta.Release(true) // meta -1
+			continue
+		}
+
+		approveeHashes := hornet.Hashes{cachedTxMeta.GetMetadata().GetTrunkHash(), cachedTxMeta.GetMetadata().GetBranchHash()}
+		for _, approveeHash := range approveeHashes {
+			if _, visited := approvers[string(approveeHash)]; visited {
+				continue
+			}
+			approvers[string(approveeHash)] = currentTxHash
+			txsToTraverse = append(txsToTraverse, approveeHash)
+		}
+		cachedTxMeta.Release(true) // meta -1
+	}
+
+	return nil, fmt.Errorf("transaction not found in the cone of milestone %d", msIndex)
+}
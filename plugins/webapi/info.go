@@ -2,24 +2,34 @@ package webapi
 
 import (
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/iotaledger/hive.go/node"
 
 	"github.com/iotaledger/iota.go/consts"
 
+	"github.com/gohornet/hornet/pkg/autopeering/services"
+	"github.com/gohornet/hornet/pkg/compressed"
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/plugins/cli"
 	"github.com/gohornet/hornet/plugins/gossip"
 	"github.com/gohornet/hornet/plugins/peering"
+	"github.com/gohornet/hornet/plugins/snapshot"
 	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
 )
 
 func init() {
 	addEndpoint("getNodeInfo", getNodeInfo, implementedAPIcalls)
 	addEndpoint("getNodeAPIConfiguration", getNodeAPIConfiguration, implementedAPIcalls)
+	addEndpoint("getProtocolParameters", getProtocolParameters, implementedAPIcalls)
+	addEndpoint("getConfirmationLatency", getConfirmationLatency, implementedAPIcalls)
+	addEndpoint("getConflictCounts", getConflictCounts, implementedAPIcalls)
+	addEndpoint("getTransactionSizeStats", getTransactionSizeStats, implementedAPIcalls)
+	addEndpoint("getPlugins", getPlugins, implementedAPIcalls)
 }
 
 func getNodeInfo(_ interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -81,6 +91,9 @@ func getNodeInfo(_ interface{}, c *gin.Context, _ <-chan struct{}) {
 		result.Features = []string{}
 	}
 
+	// the features only list the remote PoW if it is available to everyone, so state it explicitly for the caller
+	result.RemotePoW = remotePoWAvailable(c)
+
 	// Tips
 	result.Tips = metrics.SharedServerMetrics.TipsNonLazy.Load() + metrics.SharedServerMetrics.TipsSemiLazy.Load()
 
@@ -88,6 +101,17 @@ func getNodeInfo(_ interface{}, c *gin.Context, _ <-chan struct{}) {
 	queued, pending, _ := gossip.RequestQueue().Size()
 	result.TransactionsToRequest = queued + pending
 
+	// Solidification backlog
+	result.TransactionsToProcess = tangleplugin.GetReceiveTxQueueSize()
+	result.MilestonesToSolidify = tangleplugin.GetMilestonesToSolidifyCount()
+
+	// Stored transactions
+	// the amount of solid and confirmed transactions is not tracked, since this would need to scan the metadata
+	result.TransactionsStored = tangle.GetStoredTransactionsCount()
+
+	// Local snapshot creation
+	result.IsSnapshotting = snapshot.IsSnapshotting()
+
 	// Coo addr
 	result.CoordinatorAddress = config.NodeConfig.GetString(config.CfgCoordinatorAddress)
 
@@ -112,3 +136,96 @@ func getNodeAPIConfiguration(_ interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+func getProtocolParameters(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+
+	params := currentProtocolParameters()
+
+	result := GetProtocolParametersReturn{
+		NetworkID:                   params.NetworkID,
+		GossipServiceKey:            params.GossipServiceKey,
+		CoordinatorAddress:          params.CoordinatorAddress,
+		CoordinatorSecurityLevel:    params.CoordinatorSecurityLevel,
+		CoordinatorMerkleTreeDepth:  params.CoordinatorMerkleTreeDepth,
+		MilestoneMerkleTreeHashFunc: params.MilestoneMerkleTreeHashFunc,
+		MinWeightMagnitude:          params.MinWeightMagnitude,
+		TransactionSize:             params.TransactionSize,
+		BelowMaxDepth:               params.BelowMaxDepth,
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// currentProtocolParameters returns the protocol parameters a transaction has to satisfy, derived from the live config.
+func currentProtocolParameters() *ProtocolParameters {
+	return &ProtocolParameters{
+		NetworkID:                   services.NetworkID(),
+		GossipServiceKey:            string(services.GossipServiceKey()),
+		CoordinatorAddress:          config.NodeConfig.GetString(config.CfgCoordinatorAddress),
+		CoordinatorSecurityLevel:    config.NodeConfig.GetInt(config.CfgCoordinatorSecurityLevel),
+		CoordinatorMerkleTreeDepth:  config.NodeConfig.GetInt(config.CfgCoordinatorMerkleTreeDepth),
+		MilestoneMerkleTreeHashFunc: config.NodeConfig.GetString(config.CfgCoordinatorMilestoneMerkleTreeHashFunc),
+		MinWeightMagnitude:          config.NodeConfig.GetInt(config.CfgCoordinatorMWM),
+		TransactionSize:             compressed.TransactionSize,
+		BelowMaxDepth:               config.NodeConfig.GetInt(config.CfgTipSelBelowMaxDepth),
+	}
+}
+
+// getConfirmationLatency returns the p50/p95/p99 time in seconds between the solidification of recently confirmed
+// tail transactions and the timestamp of their confirming milestone.
+// the amount of considered transactions is configured by "tangle.confirmationLatencyWindow".
+func getConfirmationLatency(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	percentiles, samples := tangleplugin.GetConfirmationLatencyPercentiles(50, 95, 99)
+
+	c.JSON(http.StatusOK, GetConfirmationLatencyReturn{
+		Samples: samples,
+		P50:     percentiles[0].Seconds(),
+		P95:     percentiles[1].Seconds(),
+		P99:     percentiles[2].Seconds(),
+	})
+}
+
+// getConflictCounts returns the amount of conflicting bundles referenced by the recently confirmed milestones.
+// a rising amount of conflicts can indicate an attack on the network or misbehaving clients.
+// the amount of considered milestones is configured by "tangle.conflictsWindow".
+func getConflictCounts(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	milestones, total := tangleplugin.GetConflictCounts()
+
+	c.JSON(http.StatusOK, GetConflictCountsReturn{
+		Milestones: milestones,
+		Total:      total,
+	})
+}
+
+// getTransactionSizeStats returns the statistics of the stored sizes of the recently received transactions,
+// which can be used to estimate the growth of the database.
+// the amount of considered transactions is configured by "tangle.transactionSizesWindow".
+func getTransactionSizeStats(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	stats := tangleplugin.GetTransactionSizeStats()
+
+	c.JSON(http.StatusOK, GetTransactionSizeStatsReturn{
+		Count:   stats.Count,
+		Average: stats.Average,
+		Min:     stats.Min,
+		Max:     stats.Max,
+		P95:     stats.P95,
+	})
+}
+
+// getPlugins returns all plugins of the node and whether they are enabled or skipped by the configuration.
+func getPlugins(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	result := GetPluginsReturn{Plugins: []*PluginStatus{}}
+
+	for name, plugin := range node.GetPlugins() {
+		result.Plugins = append(result.Plugins, &PluginStatus{
+			Name:    name,
+			Enabled: !node.IsSkipped(plugin),
+		})
+	}
+
+	sort.Slice(result.Plugins, func(i, j int) bool {
+		return result.Plugins[i].Name < result.Plugins[j].Name
+	})
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,170 @@
+package webapi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+var (
+	// the ledger state hash of the latest computed milestone.
+	// computing the hash needs a full scan of the ledger, so it is only done once per milestone.
+	ledgerStateHashCache *LedgerStateHashReturn
+	// the running computation of the ledger state hash, which is shared by concurrent requests.
+	ledgerStateHashComputationRunning *ledgerStateHashComputation
+	// ledgerStateHashCacheLock guards the cache and the running computation.
+	ledgerStateHashCacheLock sync.Mutex
+)
+
+// ledgerStateHashComputation is a computation of the ledger state hash which concurrent requests wait for,
+// instead of scanning the ledger several times. it is aborted on shutdown or if all waiting clients disconnected.
+type ledgerStateHashComputation struct {
+	// the amount of requests waiting for the result, guarded by ledgerStateHashCacheLock.
+	waiters     int
+	abortSignal chan struct{}
+	abortOnce   sync.Once
+	done        chan struct{}
+	result      *LedgerStateHashReturn
+	err         error
+}
+
+// ledgerStateHashRoute returns a hash over the full ledger state as of the latest solid milestone.
+// two nodes at the same milestone produce identical hashes, so the hash can be used to audit the consistency of the ledgers.
+// the hash is the BLAKE2b-256 hash over all addresses with a balance, sorted lexicographically by their 49 byte
+// binary representation, each followed by its balance as 8 byte little endian unsigned integer (see tangle.ComputeLedgerStateHash).
+// getCheckpoint returns the same hash for any milestone, but computes it for every request. this route is meant to be
+// polled by monitoring and auditing tools, so the result is cached per milestone and concurrent requests share one computation.
+func ledgerStateHashRoute() {
+	mountRoute(http.MethodGet, "/ledger/state-hash", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["ledger"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [ledger] is protected"})
+				return
+			}
+		}
+
+		cached, computation := getCachedLedgerStateHashOrJoinComputation(tangle.GetSolidMilestoneIndex())
+		if cached != nil {
+			c.JSON(http.StatusOK, cached)
+			return
+		}
+
+		select {
+		case <-computation.done:
+		case <-c.Request.Context().Done():
+			// the client disconnected, the computation is aborted if no other client waits for it
+			computation.leave()
+			return
+		}
+
+		if computation.err != nil {
+			if computation.err == tangle.ErrOperationAborted {
+				c.JSON(http.StatusServiceUnavailable, ErrorReturn{Error: computation.err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, computation.err)})
+			return
+		}
+
+		c.JSON(http.StatusOK, computation.result)
+	})
+}
+
+// getCachedLedgerStateHashOrJoinComputation returns a copy of the cached ledger state hash if it was computed for the given milestone.
+// otherwise the running computation is returned, or a new one is started if none is running.
+func getCachedLedgerStateHashOrJoinComputation(msIndex milestone.Index) (*LedgerStateHashReturn, *ledgerStateHashComputation) {
+	ledgerStateHashCacheLock.Lock()
+	defer ledgerStateHashCacheLock.Unlock()
+
+	if ledgerStateHashCache != nil && ledgerStateHashCache.MilestoneIndex == msIndex {
+		cached := *ledgerStateHashCache
+		cached.Cached = true
+		return &cached, nil
+	}
+
+	if ledgerStateHashComputationRunning == nil {
+		ledgerStateHashComputationRunning = &ledgerStateHashComputation{
+			abortSignal: make(chan struct{}),
+			done:        make(chan struct{}),
+		}
+		go ledgerStateHashComputationRunning.run()
+	}
+	ledgerStateHashComputationRunning.waiters++
+
+	return nil, ledgerStateHashComputationRunning
+}
+
+// run computes the ledger state hash of the latest solid milestone and caches the result.
+func (computation *ledgerStateHashComputation) run() {
+	defer close(computation.done)
+
+	go func() {
+		select {
+		case <-serverShutdownSignal:
+			computation.abort()
+		case <-computation.done:
+		}
+	}()
+
+	computation.result, computation.err = computeLedgerStateHash(computation.abortSignal)
+
+	ledgerStateHashCacheLock.Lock()
+	defer ledgerStateHashCacheLock.Unlock()
+
+	if ledgerStateHashComputationRunning == computation {
+		ledgerStateHashComputationRunning = nil
+	}
+
+	if computation.err == nil && (ledgerStateHashCache == nil || ledgerStateHashCache.MilestoneIndex < computation.result.MilestoneIndex) {
+		ledgerStateHashCache = computation.result
+	}
+}
+
+// leave removes a waiting request from the computation and aborts it if it was the last one.
+func (computation *ledgerStateHashComputation) leave() {
+	ledgerStateHashCacheLock.Lock()
+	defer ledgerStateHashCacheLock.Unlock()
+
+	computation.waiters--
+	if computation.waiters > 0 {
+		return
+	}
+
+	computation.abort()
+
+	// new requests start a new computation instead of joining the aborted one
+	if ledgerStateHashComputationRunning == computation {
+		ledgerStateHashComputationRunning = nil
+	}
+}
+
+func (computation *ledgerStateHashComputation) abort() {
+	computation.abortOnce.Do(func() { close(computation.abortSignal) })
+}
+
+// computeLedgerStateHash computes the ledger state hash of the latest solid milestone.
+func computeLedgerStateHash(abortSignal <-chan struct{}) (*LedgerStateHashReturn, error) {
+	balances, ledgerIndex, err := tangle.GetLedgerStateForLSMI(abortSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerStateHash, err := tangle.ComputeLedgerStateHash(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerStateHashReturn{
+		MilestoneIndex:       ledgerIndex,
+		LedgerStateHash:      hex.EncodeToString(ledgerStateHash),
+		LedgerAddressesCount: len(balances),
+	}, nil
+}
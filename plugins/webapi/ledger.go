@@ -5,10 +5,14 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/trinary"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/dag"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/tangle"
@@ -18,6 +22,8 @@ func init() {
 	addEndpoint("getLedgerDiff", getLedgerDiff, implementedAPIcalls)
 	addEndpoint("getLedgerDiffExt", getLedgerDiffExt, implementedAPIcalls)
 	addEndpoint("getLedgerState", getLedgerState, implementedAPIcalls)
+	addEndpoint("getLedgerDiffSizes", getLedgerDiffSizes, implementedAPIcalls)
+	addEndpoint("getAddressHistory", getAddressHistory, implementedAPIcalls)
 }
 
 func getLedgerDiff(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
@@ -92,6 +98,187 @@ func getLedgerDiffExt(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	c.JSON(http.StatusOK, result)
 }
 
+func getLedgerDiffSizes(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetLedgerDiffSizes{}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	smi := tangle.GetSolidMilestoneIndex()
+	if query.EndIndex == 0 {
+		query.EndIndex = smi
+	}
+
+	if err := validateMilestoneRange(query.StartIndex, query.EndIndex, maxRequestsList); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	tangle.ReadLockLedger()
+	defer tangle.ReadUnlockLedger()
+
+	result := GetLedgerDiffSizesReturn{Sizes: []*LedgerDiffSize{}}
+	for msIndex := query.StartIndex; msIndex <= query.EndIndex; msIndex++ {
+		deposits, withdrawals, err := tangle.GetLedgerDiffSizeForMilestoneWithoutLocking(msIndex, abortSignal)
+		if err != nil {
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+		result.Sizes = append(result.Sizes, &LedgerDiffSize{MilestoneIndex: msIndex, Deposits: deposits, Withdrawals: withdrawals})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getAddressHistory returns the balance changes of an address in the ledger diffs of the given milestone range,
+// together with the confirmed value transactions of the address which caused them, ordered by milestone index.
+// the range is limited, larger histories have to be paged through by starting the next request at "nextIndex".
+func getAddressHistory(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetAddressHistory{}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if err := address.ValidAddress(query.Address); err != nil {
+		e.Error = fmt.Sprintf("Invalid address supplied: %s", query.Address)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	smi := tangle.GetSolidMilestoneIndex()
+	if query.EndIndex == 0 {
+		query.EndIndex = smi
+	}
+
+	if err := validateMilestoneRange(query.StartIndex, query.EndIndex, maxRequestsList); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	addr := hornet.HashFromAddressTrytes(query.Address)
+
+	result := GetAddressHistoryReturn{
+		Address: query.Address[:consts.HashTrytesSize],
+		History: []*AddressHistoryEntry{},
+	}
+	if query.EndIndex < smi {
+		result.NextIndex = query.EndIndex + 1
+	}
+
+	tangle.ReadLockLedger()
+	for msIndex := query.StartIndex; msIndex <= query.EndIndex; msIndex++ {
+		change, changed, err := tangle.GetLedgerDiffForAddressWithoutLocking(msIndex, addr)
+		if err != nil {
+			tangle.ReadUnlockLedger()
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+
+		if !changed {
+			continue
+		}
+		result.History = append(result.History, &AddressHistoryEntry{MilestoneIndex: msIndex, Change: change, TxHashes: []trinary.Hash{}})
+	}
+	tangle.ReadUnlockLedger()
+
+	// only the milestones which changed the balance of the address are traversed,
+	// so the work is bounded by the requested range instead of the amount of transactions of the address
+	for _, entry := range result.History {
+		txHashes, err := getConfirmedValueTxHashesForAddress(entry.MilestoneIndex, result.Address, abortSignal)
+		if err != nil {
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+		entry.TxHashes = txHashes
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getConfirmedValueTxHashesForAddress returns the hashes of the value transactions of the address,
+// which were confirmed by the given milestone and applied to the ledger.
+func getConfirmedValueTxHashesForAddress(msIndex milestone.Index, addr trinary.Hash, abortSignal <-chan struct{}) ([]trinary.Hash, error) {
+	cachedMs := tangle.GetCachedMilestoneOrNil(msIndex) // milestone +1
+	if cachedMs == nil {
+		return nil, fmt.Errorf("milestone %d not found", msIndex)
+	}
+	msHash := cachedMs.GetMilestone().Hash
+	cachedMs.Release(true) // milestone -1
+
+	txHashes := []trinary.Hash{}
+
+	err := dag.TraverseApprovees(msHash,
+		// traversal stops if no more transactions pass the given condition
+		func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed()
+			return confirmed && at == msIndex, nil
+		},
+		// consumer
+		func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			meta := cachedTxMeta.GetMetadata()
+
+			// conflicting bundles are confirmed by the milestone without being applied to the ledger
+			if !meta.IsTail() || meta.IsConflicting() {
+				return nil
+			}
+
+			cachedBndl := tangle.GetCachedBundleOrNil(meta.GetTxHash()) // bundle +1
+			if cachedBndl == nil {
+				return fmt.Errorf("bundle of tail transaction %s not found", meta.GetTxHash().Trytes())
+			}
+			defer cachedBndl.Release(true) // bundle -1
+
+			if cachedBndl.GetBundle().IsValueSpam() {
+				return nil
+			}
+
+			cachedTxs := cachedBndl.GetBundle().GetTransactions() // tx +1
+			for _, cachedTx := range cachedTxs {
+				tx := cachedTx.GetTransaction().Tx
+				if tx.Value != 0 && tx.Address == addr {
+					txHashes = append(txHashes, tx.Hash)
+				}
+			}
+			cachedTxs.Release(true) // tx -1
+
+			return nil
+		},
+		// called on missing approvees
+		func(approveeHash hornet.Hash) error {
+			return fmt.Errorf("%w: transaction %s", tangle.ErrTransactionNotFound, approveeHash.Trytes())
+		},
+		// called on solid entry points
+		nil,
+		false,
+		false,
+		abortSignal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return txHashes, nil
+}
+
 func getMilestoneStateDiff(milestoneIndex milestone.Index) (confirmedTxWithValue []*TxHashWithValue, confirmedBundlesWithValue []*BundleWithValue, totalLedgerChanges map[string]int64, err error) {
 
 	cachedReqMs := tangle.GetMilestoneOrNil(milestoneIndex) // bundle +1
@@ -102,6 +289,8 @@ func getMilestoneStateDiff(milestoneIndex milestone.Index) (confirmedTxWithValue
 	txsToConfirm := make(map[string]struct{})
 	txsToTraverse := make(map[string]struct{})
 	totalLedgerChanges = make(map[string]int64)
+	confirmedTxWithValue = []*TxHashWithValue{}
+	confirmedBundlesWithValue = []*BundleWithValue{}
 
 	txsToTraverse[string(cachedReqMs.GetBundle().GetTailHash())] = struct{}{}
 
@@ -212,6 +401,18 @@ func getLedgerState(i interface{}, c *gin.Context, abortSignal <-chan struct{})
 		return
 	}
 
+	if query.MinBalance < 0 || query.MaxBalance < 0 {
+		e.Error = "Invalid balance filter supplied, balances can't be negative"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if query.MaxBalance != 0 && query.MinBalance > query.MaxBalance {
+		e.Error = "Invalid balance filter supplied, minBalance is bigger than maxBalance"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
 	balances, index, err := tangle.GetLedgerStateForMilestone(query.TargetIndex, abortSignal)
 	if err != nil {
 		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
@@ -221,6 +422,9 @@ func getLedgerState(i interface{}, c *gin.Context, abortSignal <-chan struct{})
 
 	balancesTrytes := make(map[trinary.Trytes]uint64)
 	for address, balance := range balances {
+		if balance < uint64(query.MinBalance) || (query.MaxBalance != 0 && balance > uint64(query.MaxBalance)) {
+			continue
+		}
 		balancesTrytes[hornet.Hash(address).Trytes()] = balance
 	}
 
@@ -0,0 +1,189 @@
+package webapi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/guards"
+
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+)
+
+func init() {
+	addEndpoint("getWhiteFlagMerkleProof", getWhiteFlagMerkleProof, implementedAPIcalls)
+}
+
+// getWhiteFlagMerkleProof returns the audit path of a confirmed tail transaction in the white-flag merkle tree
+// of the milestone which confirmed it, which can be used to prove the inclusion without trusting the node.
+func getWhiteFlagMerkleProof(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetWhiteFlagMerkleProof{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if !guards.IsTransactionHash(query.TxHash) {
+		e.Error = "Invalid hash supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	txHash := hornet.HashFromHashTrytes(query.TxHash)
+
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
+	if cachedTxMeta == nil {
+		e.Error = "Transaction not found"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+	confirmed, msIndex := cachedTxMeta.GetMetadata().GetConfirmed()
+	isTail := cachedTxMeta.GetMetadata().IsTail()
+	conflicting := cachedTxMeta.GetMetadata().IsConflicting()
+	cachedTxMeta.Release(true) // meta -1
+
+	if !confirmed {
+		e.Error = "Transaction not confirmed yet"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if !isTail {
+		e.Error = "Transaction is not a tail transaction"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if conflicting {
+		e.Error = "Transaction is conflicting and not part of the merkle tree"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+	if cachedMs == nil {
+		e.Error = fmt.Sprintf("Milestone %d not found", msIndex)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+	msTailHash := cachedMs.GetBundle().GetTailHash()
+	merkleTreeHash, err := cachedMs.GetBundle().GetMilestoneMerkleTreeHash()
+	cachedMs.Release(true) // bundle -1
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	tailsIncluded, err := getWhiteFlagTailsIncluded(msIndex, msTailHash, abortSignal)
+	if err != nil {
+		if errors.Is(err, tangle.ErrOperationAborted) {
+			e.Error = err.Error()
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	leafIndex := -1
+	for i, tailHash := range tailsIncluded {
+		if string(tailHash) == string(txHash) {
+			leafIndex = i
+			break
+		}
+	}
+
+	if leafIndex == -1 {
+		e.Error = "Transaction is a zero value transaction and not part of the merkle tree"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	hasher := whiteflag.NewHasher(tangle.GetMilestoneMerkleHashFunc())
+
+	auditPath := hasher.AuditPath(tailsIncluded, leafIndex)
+	if !hasher.VerifyAuditPath(txHash, leafIndex, len(tailsIncluded), auditPath, merkleTreeHash) {
+		e.Error = fmt.Sprintf("%v: computed audit path does not match the merkle tree hash of milestone %d", ErrInternalError, msIndex)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	auditPathHex := make([]string, len(auditPath))
+	for i, hash := range auditPath {
+		auditPathHex[i] = hex.EncodeToString(hash)
+	}
+
+	c.JSON(http.StatusOK, GetWhiteFlagMerkleProofReturn{
+		MilestoneIndex:          msIndex,
+		MilestoneMerkleTreeHash: hex.EncodeToString(merkleTreeHash),
+		LeafIndex:               leafIndex,
+		LeavesCount:             len(tailsIncluded),
+		AuditPath:               auditPathHex,
+	})
+}
+
+// getWhiteFlagTailsIncluded recomputes the ordered set of tails which mutated the ledger in the given milestone.
+// the order equals the post-order depth-first search of the white-flag confirmation, the ledger state is not needed
+// since the conflicts were already stored in the metadata of the transactions at confirmation.
+func getWhiteFlagTailsIncluded(msIndex milestone.Index, msTailHash hornet.Hash, abortSignal <-chan struct{}) (hornet.Hashes, error) {
+
+	tailsIncluded := make(hornet.Hashes, 0)
+
+	err := dag.TraverseApprovees(msTailHash,
+		// traversal stops if no more transactions pass the given condition
+		func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed()
+			return confirmed && at == msIndex, nil
+		},
+		// consumer
+		func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+
+			if cachedTxMeta.GetMetadata().IsConflicting() {
+				return nil
+			}
+
+			cachedBundle := tangle.GetCachedBundleOrNil(cachedTxMeta.GetMetadata().GetTxHash()) // bundle +1
+			if cachedBundle == nil {
+				return fmt.Errorf("%w: bundle %s of candidate tx %s doesn't exist", tangle.ErrBundleNotFound, cachedTxMeta.GetMetadata().GetBundleHash().Trytes(), cachedTxMeta.GetMetadata().GetTxHash().Trytes())
+			}
+			defer cachedBundle.Release(true) // bundle -1
+
+			// zero or spam value bundles are not part of the merkle tree
+			if cachedBundle.GetBundle().IsValueSpam() || len(cachedBundle.GetBundle().GetLedgerChanges()) == 0 {
+				return nil
+			}
+
+			tailsIncluded = append(tailsIncluded, cachedTxMeta.GetMetadata().GetTxHash())
+			return nil
+		},
+		// called on missing approvees
+		func(approveeHash hornet.Hash) error {
+			return fmt.Errorf("%w: transaction %s", tangle.ErrTransactionNotFound, approveeHash.Trytes())
+		},
+		// called on solid entry points
+		nil,
+		false,
+		true,
+		abortSignal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tailsIncluded, nil
+}
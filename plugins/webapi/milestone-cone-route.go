@@ -0,0 +1,95 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// milestoneConeStatusRoute reports whether all transactions confirmed by the milestone with the given index are still stored.
+// the cone is traversed until the transactions of older milestones or the solid entry points are reached,
+// so every approvee which can't be found was part of the cone, since older transactions are kept as solid entry points.
+func milestoneConeStatusRoute() {
+	mountRoute(http.MethodGet, "/milestones/:index/cone-status", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["milestones"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [milestones] is protected"})
+				return
+			}
+		}
+
+		msIndexParam, err := strconv.ParseUint(c.Param("index"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid milestone index: %s", c.Param("index"))})
+			return
+		}
+		msIndex := milestone.Index(msIndexParam)
+
+		if smi := tangle.GetSolidMilestoneIndex(); msIndex > smi {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid milestone index: %d, lsmi is %d", msIndex, smi)})
+			return
+		}
+
+		// the cones of pruned milestones were removed from the database
+		if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil && msIndex <= snapshotInfo.PruningIndex {
+			c.JSON(http.StatusOK, MilestoneConeStatusReturn{MilestoneIndex: msIndex, Pruned: true})
+			return
+		}
+
+		cachedMs := tangle.GetCachedMilestoneOrNil(msIndex) // milestone +1
+		if cachedMs == nil {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("milestone not found: %d", msIndex), Code: ErrCodeNotFound})
+			return
+		}
+		msHash := cachedMs.GetMilestone().Hash
+		cachedMs.Release(true) // milestone -1
+
+		result := MilestoneConeStatusReturn{MilestoneIndex: msIndex}
+
+		err = dag.TraverseApprovees(msHash,
+			// traversal stops if no more transactions pass the given condition
+			func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+				defer cachedTxMeta.Release(true) // meta -1
+				confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed()
+				return confirmed && at == msIndex, nil
+			},
+			// consumer
+			func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+				defer cachedTxMeta.Release(true) // meta -1
+				result.Present++
+				return nil
+			},
+			// called on missing approvees
+			func(approveeHash hornet.Hash) error {
+				result.Missing++
+				return nil
+			},
+			// called on solid entry points
+			nil,
+			false,
+			false,
+			serverShutdownSignal)
+
+		if err != nil {
+			if errors.Is(err, tangle.ErrOperationAborted) {
+				c.JSON(http.StatusServiceUnavailable, ErrorReturn{Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, err)})
+			return
+		}
+		result.Complete = result.Missing == 0
+
+		c.JSON(http.StatusOK, result)
+	})
+}
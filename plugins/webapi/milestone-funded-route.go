@@ -0,0 +1,111 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// milestoneFundedAddressesRoute returns the addresses which received funds in the milestone with the given index,
+// ordered by address. the result is paginated, the "cursor" query parameter is the last address of the previous page.
+// the ledger diff of a milestone only contains the net change per address,
+// so an address which received and spent the same amount in the milestone is not listed.
+func milestoneFundedAddressesRoute() {
+	mountRoute(http.MethodGet, "/milestones/:index/funded-addresses", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["milestones"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [milestones] is protected"})
+				return
+			}
+		}
+
+		msIndexParam, err := strconv.ParseUint(c.Param("index"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid milestone index: %s", c.Param("index"))})
+			return
+		}
+		msIndex := milestone.Index(msIndexParam)
+
+		maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+		limit := maxRequestsList
+		if limitQuery := c.Query("limit"); limitQuery != "" {
+			if limit, err = strconv.Atoi(limitQuery); err != nil || limit <= 0 || limit > maxRequestsList {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid limit: %s, max. %d", limitQuery, maxRequestsList)})
+				return
+			}
+		}
+
+		cursor := c.Query("cursor")
+		if cursor != "" {
+			if err := address.ValidAddress(cursor); err != nil {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid cursor: %s", cursor)})
+				return
+			}
+			// the cursor is compared with the addresses without checksum
+			cursor = cursor[:consts.HashTrytesSize]
+		}
+
+		// the ledger diffs of pruned milestones are not available anymore
+		snapshotInfo := tangle.GetSnapshotInfo()
+		if msIndex > tangle.GetSolidMilestoneIndex() || (snapshotInfo != nil && msIndex <= snapshotInfo.PruningIndex) {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("milestone not available: %d", msIndex), Code: ErrCodeNotFound})
+			return
+		}
+
+		diff, err := tangle.GetLedgerDiffForMilestone(msIndex, serverShutdownSignal)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, err)})
+			return
+		}
+
+		result := &MilestoneFundedAddressesReturn{
+			MilestoneIndex: msIndex,
+			Addresses:      []*FundedAddress{},
+		}
+
+		var addresses []trinary.Hash
+		amounts := make(map[trinary.Hash]uint64)
+		for addr, change := range diff {
+			if change <= 0 {
+				continue
+			}
+
+			addrTrytes := hornet.Hash(addr).Trytes()
+			addresses = append(addresses, addrTrytes)
+			amounts[addrTrytes] = uint64(change)
+			result.AddressesCount++
+			result.TotalAmount += uint64(change)
+		}
+		sort.Strings(addresses)
+
+		// skip the addresses up to the cursor
+		start := sort.SearchStrings(addresses, cursor)
+		if start < len(addresses) && addresses[start] == cursor {
+			start++
+		}
+
+		for _, addr := range addresses[start:] {
+			if len(result.Addresses) == limit {
+				result.NextCursor = result.Addresses[len(result.Addresses)-1].Address
+				break
+			}
+			result.Addresses = append(result.Addresses, &FundedAddress{Address: addr, Amount: amounts[addr]})
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
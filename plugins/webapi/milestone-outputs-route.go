@@ -0,0 +1,213 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/iota.go/guards"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+var (
+	// the outputs of the last requested milestone are cached, since clients page through them with several requests
+	milestoneOutputsCache      []*MilestoneOutput
+	milestoneOutputsCacheIndex milestone.Index
+	milestoneOutputsCacheLock  sync.Mutex
+)
+
+// milestoneOutputsRoute returns the outputs created in the milestone with the given index,
+// i.e. the transactions with a positive value of the non-conflicting value bundles confirmed by the milestone, ordered by transaction hash.
+// the outputs can be filtered by their value with the "minAmount" and "maxAmount" query parameters.
+// the result is paginated, the "cursor" query parameter is the last transaction hash of the previous page.
+func milestoneOutputsRoute() {
+	mountRoute(http.MethodGet, "/milestones/:index/outputs", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["milestones"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [milestones] is protected"})
+				return
+			}
+		}
+
+		msIndexParam, err := strconv.ParseUint(c.Param("index"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid milestone index: %s", c.Param("index"))})
+			return
+		}
+		msIndex := milestone.Index(msIndexParam)
+
+		maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+		limit := maxRequestsList
+		if limitQuery := c.Query("limit"); limitQuery != "" {
+			if limit, err = strconv.Atoi(limitQuery); err != nil || limit <= 0 || limit > maxRequestsList {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid limit: %s, max. %d", limitQuery, maxRequestsList)})
+				return
+			}
+		}
+
+		var minAmount, maxAmount int64
+		if minAmountQuery := c.Query("minAmount"); minAmountQuery != "" {
+			if minAmount, err = strconv.ParseInt(minAmountQuery, 10, 64); err != nil || minAmount < 0 {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid minAmount: %s", minAmountQuery)})
+				return
+			}
+		}
+		if maxAmountQuery := c.Query("maxAmount"); maxAmountQuery != "" {
+			if maxAmount, err = strconv.ParseInt(maxAmountQuery, 10, 64); err != nil || maxAmount <= 0 {
+				c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid maxAmount: %s", maxAmountQuery)})
+				return
+			}
+		}
+		if maxAmount != 0 && minAmount > maxAmount {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: "invalid amount filter supplied, minAmount is bigger than maxAmount"})
+			return
+		}
+
+		cursor := c.Query("cursor")
+		if cursor != "" && !guards.IsTransactionHash(cursor) {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid cursor: %s", cursor)})
+			return
+		}
+
+		// the cones of pruned milestones are not available anymore
+		snapshotInfo := tangle.GetSnapshotInfo()
+		if msIndex > tangle.GetSolidMilestoneIndex() || (snapshotInfo != nil && msIndex <= snapshotInfo.PruningIndex) {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("milestone not available: %d", msIndex), Code: ErrCodeNotFound})
+			return
+		}
+
+		milestoneOutputs, err := getMilestoneOutputs(msIndex, serverShutdownSignal)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, err)})
+			return
+		}
+
+		var outputs []*MilestoneOutput
+		for _, output := range milestoneOutputs {
+			if int64(output.Value) < minAmount || (maxAmount != 0 && int64(output.Value) > maxAmount) {
+				continue
+			}
+			outputs = append(outputs, output)
+		}
+
+		result := &MilestoneOutputsReturn{
+			MilestoneIndex: msIndex,
+			Outputs:        []*MilestoneOutput{},
+			OutputsCount:   len(outputs),
+		}
+
+		// skip the outputs up to the cursor
+		start := sort.Search(len(outputs), func(i int) bool {
+			return outputs[i].TxHash >= cursor
+		})
+		if start < len(outputs) && outputs[start].TxHash == cursor {
+			start++
+		}
+
+		for _, output := range outputs[start:] {
+			if len(result.Outputs) == limit {
+				result.NextCursor = result.Outputs[len(result.Outputs)-1].TxHash
+				break
+			}
+			result.Outputs = append(result.Outputs, output)
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// getMilestoneOutputs returns the outputs created in the given milestone, ordered by transaction hash.
+// conflicting bundles are confirmed by the milestone without being applied to the ledger, so they are ignored.
+func getMilestoneOutputs(msIndex milestone.Index, abortSignal <-chan struct{}) ([]*MilestoneOutput, error) {
+	milestoneOutputsCacheLock.Lock()
+	defer milestoneOutputsCacheLock.Unlock()
+
+	if milestoneOutputsCache != nil && milestoneOutputsCacheIndex == msIndex {
+		return milestoneOutputsCache, nil
+	}
+
+	cachedMs := tangle.GetCachedMilestoneOrNil(msIndex) // milestone +1
+	if cachedMs == nil {
+		return nil, fmt.Errorf("milestone %d not found", msIndex)
+	}
+	msHash := cachedMs.GetMilestone().Hash
+	cachedMs.Release(true) // milestone -1
+
+	outputs := []*MilestoneOutput{}
+
+	err := dag.TraverseApprovees(msHash,
+		// traversal stops if no more transactions pass the given condition
+		func(cachedTxMeta *tangle.CachedMetadata) (bool, error) { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			confirmed, at := cachedTxMeta.GetMetadata().GetConfirmed()
+			return confirmed && at == msIndex, nil
+		},
+		// consumer
+		func(cachedTxMeta *tangle.CachedMetadata) error { // meta +1
+			defer cachedTxMeta.Release(true) // meta -1
+			meta := cachedTxMeta.GetMetadata()
+
+			if !meta.IsTail() || meta.IsConflicting() {
+				return nil
+			}
+
+			cachedBndl := tangle.GetCachedBundleOrNil(meta.GetTxHash()) // bundle +1
+			if cachedBndl == nil {
+				return fmt.Errorf("bundle of tail transaction %s not found", meta.GetTxHash().Trytes())
+			}
+			defer cachedBndl.Release(true) // bundle -1
+
+			if !cachedBndl.GetBundle().IsValueSpam() {
+				cachedTxs := cachedBndl.GetBundle().GetTransactions() // tx +1
+				for _, cachedTx := range cachedTxs {
+					tx := cachedTx.GetTransaction().Tx
+					if tx.Value <= 0 {
+						continue
+					}
+					outputs = append(outputs, &MilestoneOutput{
+						TxHash:     tx.Hash,
+						TailTxHash: meta.GetTxHash().Trytes(),
+						BundleHash: tx.Bundle,
+						Address:    tx.Address,
+						Value:      uint64(tx.Value),
+					})
+				}
+				cachedTxs.Release(true) // tx -1
+			}
+
+			return nil
+		},
+		// called on missing approvees
+		func(approveeHash hornet.Hash) error {
+			return fmt.Errorf("%w: transaction %s", tangle.ErrTransactionNotFound, approveeHash.Trytes())
+		},
+		// called on solid entry points
+		nil,
+		false,
+		false,
+		abortSignal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].TxHash < outputs[j].TxHash
+	})
+
+	milestoneOutputsCache = outputs
+	milestoneOutputsCacheIndex = msIndex
+
+	return outputs, nil
+}
@@ -0,0 +1,76 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// milestoneByIndexRoute returns the milestone with the given index.
+// milestones are immutable once they were issued, so the hash of the milestone is used as ETag
+// and clients which already know the milestone receive a "304 Not Modified" if they send it in the "If-None-Match" header.
+func milestoneByIndexRoute() {
+	mountRoute(http.MethodGet, "/milestones/:index", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["milestones"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [milestones] is protected"})
+				return
+			}
+		}
+
+		msIndex, err := strconv.ParseUint(c.Param("index"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid milestone index: %s", c.Param("index"))})
+			return
+		}
+
+		// a not yet issued milestone must never match a cached ETag
+		cachedMs := tangle.GetMilestoneOrNil(milestone.Index(msIndex)) // bundle +1
+		if cachedMs == nil {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("milestone not found: %d", msIndex), Code: ErrCodeNotFound})
+			return
+		}
+		defer cachedMs.Release(true) // bundle -1
+
+		msHash := cachedMs.GetBundle().GetMilestoneHash().Trytes()
+		etag := strconv.Quote(msHash)
+
+		c.Header("ETag", etag)
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+
+		cachedTailTx := cachedMs.GetBundle().GetTail() // tx +1
+		if cachedTailTx == nil {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("milestone not found: %d", msIndex), Code: ErrCodeNotFound})
+			return
+		}
+		defer cachedTailTx.Release(true) // tx -1
+
+		c.JSON(http.StatusOK, MilestoneByIndexReturn{
+			MilestoneIndex: milestone.Index(msIndex),
+			MilestoneHash:  msHash,
+			Timestamp:      cachedTailTx.GetTransaction().GetTimestamp(),
+		})
+	})
+}
+
+// etagMatches checks whether the given "If-None-Match" header contains the ETag.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
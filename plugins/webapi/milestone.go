@@ -0,0 +1,318 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/plugins/curl"
+	"github.com/gohornet/hornet/plugins/gossip"
+)
+
+func init() {
+	addEndpoint("submitMilestone", submitMilestone, implementedAPIcalls)
+	addEndpoint("getMilestoneHashes", getMilestoneHashes, implementedAPIcalls)
+	addEndpoint("getMilestonesByTimestamp", getMilestonesByTimestamp, implementedAPIcalls)
+}
+
+// submitMilestone accepts the bundle of a milestone which was issued by an external coordinator on a private network.
+// the milestone is validated against the configured coordinator address and has to be the next milestone index.
+// afterwards it is processed like a milestone received via gossip, which triggers the confirmation of its cone.
+func submitMilestone(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &SubmitMilestone{}
+
+	if !config.NodeConfig.GetBool(config.CfgWebAPIAllowSubmitMilestone) {
+		e.Error = fmt.Sprintf("submitting milestones is disabled, enable it via \"%s\"", config.CfgWebAPIAllowSubmitMilestone)
+		c.JSON(http.StatusForbidden, e)
+		return
+	}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if len(query.Trytes) == 0 {
+		e.Error = "No trytes provided"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	txs := make([]*transaction.Transaction, 0, len(query.Trytes))
+	for _, trytes := range query.Trytes {
+		if !guards.IsTransactionTrytes(trytes) {
+			e.Error = consts.ErrInvalidTransactionTrytes.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		txTrits := trinary.MustTrytesToTrits(trytes)
+		tx, err := transaction.ParseTransaction(txTrits, true)
+		if err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		hashTrits, err := curl.Hasher().Hash(txTrits)
+		if err != nil {
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+		tx.Hash = trinary.MustTritsToTrytes(hashTrits)
+
+		txs = append(txs, tx)
+	}
+
+	// Sort transactions (lowest to highest index)
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].CurrentIndex < txs[j].CurrentIndex
+	})
+
+	msIndex, err := tangle.ValidateMilestoneTransactions(txs)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if expectedIndex := tangle.GetLatestMilestoneIndex() + 1; msIndex != expectedIndex {
+		e.Error = fmt.Sprintf("Invalid milestone index: %d, expected: %d", msIndex, expectedIndex)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	for _, trytes := range query.Trytes {
+		if err := gossip.Processor().ValidateTransactionTrytesAndEmit(trytes); err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, SubmitMilestoneReturn{MilestoneIndex: msIndex, MilestoneHash: txs[0].Hash})
+}
+
+// validateMilestoneRange checks that the given milestone range is not empty, only contains solid milestones
+// which were not pruned yet and doesn't exceed the given maximum amount of milestones.
+func validateMilestoneRange(startIndex milestone.Index, endIndex milestone.Index, maxMilestones int) error {
+	var pruningIndex milestone.Index
+	if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil {
+		pruningIndex = snapshotInfo.PruningIndex
+	}
+
+	return checkMilestoneRange(startIndex, endIndex, maxMilestones, tangle.GetSolidMilestoneIndex(), pruningIndex)
+}
+
+// checkMilestoneRange checks the given milestone range against the given solid milestone and pruning index.
+func checkMilestoneRange(startIndex milestone.Index, endIndex milestone.Index, maxMilestones int, smi milestone.Index, pruningIndex milestone.Index) error {
+	if startIndex == 0 || startIndex > endIndex {
+		return errors.New("Invalid milestone range supplied")
+	}
+
+	if endIndex > smi {
+		return fmt.Errorf("Invalid milestone index supplied, lsmi is %d", smi)
+	}
+
+	if startIndex <= pruningIndex {
+		return fmt.Errorf("Invalid milestone index supplied, pruning index is %d", pruningIndex)
+	}
+
+	if int(endIndex-startIndex)+1 > maxMilestones {
+		return fmt.Errorf("Too many milestones requested. Max. allowed: %d", maxMilestones)
+	}
+
+	return nil
+}
+
+// getMilestoneHashes returns the hashes of the milestones in the given range, or of the latest N solid milestones,
+// so that a client can compare the milestone chain of the node with a trusted source.
+func getMilestoneHashes(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetMilestoneHashes{}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	smi := tangle.GetSolidMilestoneIndex()
+
+	if query.Latest != 0 {
+		if query.StartIndex != 0 || query.EndIndex != 0 {
+			e.Error = "latest can't be combined with a milestone range"
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		if query.Latest < 0 {
+			e.Error = "Invalid amount of latest milestones supplied"
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		if query.Latest > maxRequestsList {
+			e.Error = "Too many milestones requested. Max. allowed: " + strconv.Itoa(maxRequestsList)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		query.EndIndex = smi
+		query.StartIndex = 1
+		if smi > milestone.Index(query.Latest) {
+			query.StartIndex = smi - milestone.Index(query.Latest) + 1
+		}
+		if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil && query.StartIndex <= snapshotInfo.PruningIndex {
+			query.StartIndex = snapshotInfo.PruningIndex + 1
+		}
+	}
+
+	if query.EndIndex == 0 {
+		query.EndIndex = smi
+	}
+
+	if err := validateMilestoneRange(query.StartIndex, query.EndIndex, maxRequestsList); err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetMilestoneHashesReturn{Milestones: []*MilestoneHash{}}
+	for msIndex := query.StartIndex; msIndex <= query.EndIndex; msIndex++ {
+		cachedMs := tangle.GetCachedMilestoneOrNil(msIndex) // milestone +1
+		if cachedMs == nil {
+			e.Error = fmt.Sprintf("Milestone %d not found", msIndex)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+
+		result.Milestones = append(result.Milestones, &MilestoneHash{
+			MilestoneIndex: msIndex,
+			MilestoneHash:  cachedMs.GetMilestone().Hash.Trytes(),
+		})
+		cachedMs.Release(true) // milestone -1
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getMilestonesByTimestamp returns the solid milestones with a timestamp within the given range.
+// the range is resolved to milestone indexes by a binary search over the milestone timestamps,
+// timestamps outside of the available milestones are clamped to the pruning index and the latest solid milestone.
+func getMilestonesByTimestamp(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetMilestonesByTimestamp{}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if query.ToTimestamp == 0 {
+		query.ToTimestamp = time.Now().Unix()
+	}
+
+	if query.FromTimestamp < 0 || query.FromTimestamp > query.ToTimestamp {
+		e.Error = "Invalid timestamp range supplied"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	result := GetMilestonesByTimestampReturn{Milestones: []*MilestoneByIndexReturn{}}
+
+	startIndex := milestone.Index(1)
+	if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil {
+		startIndex = snapshotInfo.PruningIndex + 1
+	}
+	endIndex := tangle.GetSolidMilestoneIndex()
+
+	if startIndex > endIndex {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	var searchErr error
+	searchMilestone := func(condition func(msTimestamp int64) bool) milestone.Index {
+		// the first milestone for which the condition is true
+		return startIndex + milestone.Index(sort.Search(int(endIndex-startIndex)+1, func(i int) bool {
+			_, msTimestamp, err := getMilestoneHashAndTimestamp(startIndex + milestone.Index(i))
+			if err != nil {
+				searchErr = err
+				return true
+			}
+			return condition(msTimestamp)
+		}))
+	}
+
+	fromIndex := searchMilestone(func(msTimestamp int64) bool { return msTimestamp >= query.FromTimestamp })
+	// compared with ">" instead of adding 1 to the timestamp, which would overflow for the maximum timestamp
+	toIndex := searchMilestone(func(msTimestamp int64) bool { return msTimestamp > query.ToTimestamp })
+	if searchErr != nil {
+		e.Error = searchErr.Error()
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	for msIndex := fromIndex; msIndex < toIndex; msIndex++ {
+		if len(result.Milestones) >= maxRequestsList {
+			result.Truncated = true
+			break
+		}
+
+		msHash, msTimestamp, err := getMilestoneHashAndTimestamp(msIndex)
+		if err != nil {
+			e.Error = err.Error()
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+
+		result.Milestones = append(result.Milestones, &MilestoneByIndexReturn{
+			MilestoneIndex: msIndex,
+			MilestoneHash:  msHash.Trytes(),
+			Timestamp:      msTimestamp,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getMilestoneHashAndTimestamp returns the hash and the timestamp of the tail transaction of the milestone with the given index.
+func getMilestoneHashAndTimestamp(msIndex milestone.Index) (hornet.Hash, int64, error) {
+	cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+	if cachedMs == nil {
+		return nil, 0, fmt.Errorf("Milestone %d not found", msIndex)
+	}
+	defer cachedMs.Release(true) // bundle -1
+
+	cachedTailTx := cachedMs.GetBundle().GetTail() // tx +1
+	if cachedTailTx == nil {
+		return nil, 0, fmt.Errorf("Milestone %d not found", msIndex)
+	}
+	defer cachedTailTx.Release(true) // tx -1
+
+	return cachedMs.GetBundle().GetMilestoneHash(), cachedTailTx.GetTransaction().GetTimestamp(), nil
+}
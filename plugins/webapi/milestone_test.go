@@ -0,0 +1,38 @@
+package webapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gohornet/hornet/pkg/model/milestone"
+)
+
+func TestCheckMilestoneRange(t *testing.T) {
+	const smi = 100
+	const pruningIndex = 10
+	const maxMilestones = 20
+
+	tests := []struct {
+		name       string
+		startIndex milestone.Index
+		endIndex   milestone.Index
+		valid      bool
+	}{
+		{"valid range", 81, 100, true},
+		{"single milestone", 50, 50, true},
+		{"missing start", 0, 50, false},
+		{"start after end", 51, 50, false},
+		{"end after solid milestone", 90, 101, false},
+		{"start at pruning index", 10, 20, false},
+		{"start after pruning index", 11, 20, true},
+		{"too many milestones", 80, 100, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkMilestoneRange(test.startIndex, test.endIndex, maxMilestones, smi, pruningIndex)
+			assert.Equal(t, test.valid, err == nil, err)
+		})
+	}
+}
@@ -3,19 +3,36 @@ package webapi
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 
+	"github.com/iotaledger/hive.go/node"
+
 	"github.com/gohornet/hornet/pkg/config"
+	peeringpkg "github.com/gohornet/hornet/pkg/peering"
+	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/plugins/autopeering"
+	"github.com/gohornet/hornet/plugins/gossip"
 	"github.com/gohornet/hornet/plugins/peering"
 )
 
+const (
+	pingNeighborsTimeout = 5 * time.Second
+)
+
 func init() {
 	addEndpoint("addNeighbors", addNeighbors, implementedAPIcalls)
 	addEndpoint("removeNeighbors", removeNeighbors, implementedAPIcalls)
 	addEndpoint("getNeighbors", getNeighbors, implementedAPIcalls)
+	addEndpoint("resetNeighborMetrics", resetNeighborMetrics, implementedAPIcalls)
+	addEndpoint("pingNeighbors", pingNeighbors, implementedAPIcalls)
+	addEndpoint("getNodeIdentity", getNodeIdentity, implementedAPIcalls)
+	addEndpoint("getNeighborErrors", getNeighborErrors, implementedAPIcalls)
 }
 
 func addNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -218,3 +235,147 @@ func removeNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
 func getNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	c.JSON(http.StatusOK, GetNeighborsReturn{Neighbors: peering.Manager().PeerInfos()})
 }
+
+// resetNeighborMetrics zeroes the gossip metrics of a connected neighbor without disconnecting it.
+// the metrics as they were before the reset are returned, so no data is lost.
+func resetNeighborMetrics(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &ResetNeighborMetrics{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	identity := query.Identity
+	if strings.Contains(identity, "tcp://") {
+		identity = identity[6:]
+	}
+
+	info, err := peering.Manager().ResetPeerMetrics(identity)
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %s", err, query.Identity)
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, ResetNeighborMetricsReturn{Neighbor: info})
+}
+
+// pingNeighbors measures the round-trip time to the given connected neighbor or to all connected neighbors if no identity is given.
+func pingNeighbors(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &PingNeighbors{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	identity := query.Identity
+	if strings.Contains(identity, "tcp://") {
+		identity = identity[6:]
+	}
+
+	var peers []*peer.Peer
+	peering.Manager().ForAllConnected(func(p *peer.Peer) bool {
+		if identity == "" || p.ID == identity || p.InitAddress.String() == identity {
+			peers = append(peers, p)
+		}
+		return true
+	})
+
+	if identity != "" && len(peers) == 0 {
+		e.Error = fmt.Sprintf("%v: %s", peeringpkg.ErrUnknownPeerID, query.Identity)
+		c.JSON(http.StatusNotFound, e)
+		return
+	}
+
+	result := PingNeighborsReturn{Pings: make([]*NeighborPing, len(peers))}
+
+	var wg sync.WaitGroup
+	for index, p := range peers {
+		wg.Add(1)
+		go func(index int, p *peer.Peer) {
+			defer wg.Done()
+
+			ping := &NeighborPing{Identity: p.ID}
+			rtt, cached, err := gossip.Ping(p, pingNeighborsTimeout)
+			if err != nil {
+				ping.Error = err.Error()
+			}
+			ping.RTTMs = rtt.Milliseconds()
+			ping.Cached = cached
+			result.Pings[index] = ping
+		}(index, p)
+	}
+	wg.Wait()
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getNodeIdentity returns the information other nodes need to peer with this node.
+// the bind addresses may contain an unspecified IP, which has to be replaced by the public IP of the node.
+func getNodeIdentity(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+
+	result := GetNodeIdentityReturn{
+		GossipBindAddress: config.NodeConfig.GetString(config.CfgNetGossipBindAddress),
+	}
+
+	if !node.IsSkipped(autopeering.PLUGIN) && autopeering.ID != "" {
+		result.Autopeering = &AutopeeringIdentity{
+			ID:          autopeering.ID,
+			PublicKey:   autopeering.PublicKey,
+			BindAddress: config.NodeConfig.GetString(config.CfgNetAutopeeringBindAddr),
+		}
+		result.Autopeering.EntryNode = fmt.Sprintf("%s@%s", result.Autopeering.PublicKey, result.Autopeering.BindAddress)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getNeighborErrors returns the recent gossip protocol errors caused by the given neighbor or by all neighbors if no identity is given.
+// the errors are also kept for neighbors that were already disconnected because of them.
+func getNeighborErrors(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetNeighborErrors{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	identity := query.Identity
+	if strings.Contains(identity, "tcp://") {
+		identity = identity[6:]
+	}
+
+	result := GetNeighborErrorsReturn{Neighbors: []*NeighborErrors{}}
+
+	if identity != "" {
+		// the neighbor could also be identified by its origin address, i.e node.example.com:15600
+		peering.Manager().ForAllConnected(func(p *peer.Peer) bool {
+			if p.InitAddress != nil && p.InitAddress.String() == identity {
+				identity = p.ID
+				return false
+			}
+			return true
+		})
+
+		result.Neighbors = append(result.Neighbors, &NeighborErrors{Identity: identity, Errors: peering.Manager().PeerErrors(identity)})
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	for id, peerErrors := range peering.Manager().AllPeerErrors() {
+		result.Neighbors = append(result.Neighbors, &NeighborErrors{Identity: id, Errors: peerErrors})
+	}
+	sort.Slice(result.Neighbors, func(i, j int) bool {
+		return result.Neighbors[i].Identity < result.Neighbors[j].Identity
+	})
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,157 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/plugins/peering"
+)
+
+const (
+	// PeersImportModeMerge adds the imported peers and updates the alias of already configured ones.
+	PeersImportModeMerge = "merge"
+	// PeersImportModeReplace additionally removes all configured peers which are not part of the import.
+	PeersImportModeReplace = "replace"
+
+	PeerImportStatusAdded     = "added"
+	PeerImportStatusUpdated   = "updated"
+	PeerImportStatusUnchanged = "unchanged"
+	PeerImportStatusRemoved   = "removed"
+	PeerImportStatusFailed    = "failed"
+)
+
+// peersExportRoute returns the configured static peers, the result can be restored on another node via peersImportRoute.
+func peersExportRoute() {
+	mountRoute(http.MethodGet, "/peers/export", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["peers"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [peers] is protected"})
+				return
+			}
+		}
+
+		configPeers := []config.PeerConfig{}
+		if err := config.PeeringConfig.UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, err)})
+			return
+		}
+
+		c.JSON(http.StatusOK, PeersExport{Peers: configPeers})
+	})
+}
+
+// peersImportRoute restores an export of peersExportRoute into the peering config and the peering manager.
+// the query parameter "mode" selects whether the peers are merged into the configured peers (default)
+// or replace them, in which case configured peers missing in the import are removed.
+func peersImportRoute() {
+	mountRoute(http.MethodPost, "/peers/import", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["peers"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [peers] is protected"})
+				return
+			}
+		}
+
+		mode := strings.ToLower(c.DefaultQuery("mode", PeersImportModeMerge))
+		if mode != PeersImportModeMerge && mode != PeersImportModeReplace {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid mode: %s, must be one of [%s, %s]", mode, PeersImportModeMerge, PeersImportModeReplace)})
+			return
+		}
+
+		peersImport := &PeersExport{}
+		if err := c.ShouldBindJSON(peersImport); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: err.Error()})
+			return
+		}
+
+		var configPeers []config.PeerConfig
+		if err := config.PeeringConfig.UnmarshalKey(config.CfgPeers, &configPeers); err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, err)})
+			return
+		}
+
+		result := PeersImportReturn{Mode: mode, Results: []*PeerImportResult{}}
+		importedIDs := make(map[string]struct{})
+		modified := false
+
+		for _, importedPeer := range peersImport.Peers {
+			importedPeer.ID = strings.TrimPrefix(importedPeer.ID, "tcp://")
+			if _, duplicate := importedIDs[strings.ToLower(importedPeer.ID)]; duplicate {
+				continue
+			}
+			importedIDs[strings.ToLower(importedPeer.ID)] = struct{}{}
+
+			peerResult := &PeerImportResult{Identity: importedPeer.ID, Alias: importedPeer.Alias}
+			result.Results = append(result.Results, peerResult)
+
+			existing := -1
+			for i := range configPeers {
+				if strings.EqualFold(configPeers[i].ID, importedPeer.ID) {
+					existing = i
+					break
+				}
+			}
+
+			if existing != -1 {
+				// the settings of known peers are only applied on the next reconnect
+				if configPeers[existing] == importedPeer {
+					peerResult.Status = PeerImportStatusUnchanged
+					continue
+				}
+				configPeers[existing] = importedPeer
+				peerResult.Status = PeerImportStatusUpdated
+				modified = true
+				continue
+			}
+
+			if err := peering.Manager().Add(importedPeer.ID, importedPeer.PreferIPv6, importedPeer.Alias); err != nil {
+				peerResult.Status = PeerImportStatusFailed
+				peerResult.Error = err.Error()
+				continue
+			}
+
+			configPeers = append(configPeers, importedPeer)
+			peerResult.Status = PeerImportStatusAdded
+			modified = true
+		}
+
+		if mode == PeersImportModeReplace {
+			var remainingPeers []config.PeerConfig
+			for _, configPeer := range configPeers {
+				if _, imported := importedIDs[strings.ToLower(configPeer.ID)]; imported {
+					remainingPeers = append(remainingPeers, configPeer)
+					continue
+				}
+
+				peerResult := &PeerImportResult{Identity: configPeer.ID, Alias: configPeer.Alias, Status: PeerImportStatusRemoved}
+				if err := peering.Manager().Remove(configPeer.ID); err != nil {
+					// keep the peer in the config, so the config matches the peers of the manager
+					peerResult.Status = PeerImportStatusFailed
+					peerResult.Error = err.Error()
+					remainingPeers = append(remainingPeers, configPeer)
+				} else {
+					modified = true
+				}
+				result.Results = append(result.Results, peerResult)
+			}
+			configPeers = remainingPeers
+		}
+
+		if modified {
+			config.DenyPeeringConfigHotReload()
+			config.PeeringConfig.Set(config.CfgPeers, configPeers)
+			config.PeeringConfig.WriteConfig()
+			config.AllowPeeringConfigHotReload()
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,73 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/address"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("getPendingDeposits", getPendingDeposits, implementedAPIcalls)
+}
+
+// getPendingDeposits returns the solid but not yet confirmed transactions which deposit tokens on the given address.
+// these deposits are not part of the ledger state and could still become conflicting or never be confirmed.
+// every value transaction of the address has to be loaded from the database, so the amount of
+// checked transactions is capped by "httpAPI.limits.findTransactions".
+func getPendingDeposits(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetPendingDeposits{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if err := address.ValidAddress(query.Address); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", err, query.Address)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if !tangle.WaitForNodeSynced(waitForNodeSyncedTimeout) {
+		e.Error = ErrNodeNotSync.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	maxFind := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions)
+
+	result := GetPendingDepositsReturn{Deposits: []*PendingDeposit{}}
+
+	txHashes := tangle.GetTransactionHashesForAddress(hornet.HashFromAddressTrytes(query.Address), true, true, maxFind)
+	for _, txHash := range txHashes {
+		cachedTx := tangle.GetCachedTransactionOrNil(txHash) // tx +1
+		if cachedTx == nil {
+			continue
+		}
+
+		metadata := cachedTx.GetMetadata()
+		if metadata.IsSolid() && !metadata.IsConfirmed() && cachedTx.GetTransaction().Tx.Value > 0 {
+			result.Deposits = append(result.Deposits, &PendingDeposit{
+				TxHash:     txHash.Trytes(),
+				BundleHash: cachedTx.GetTransaction().Tx.Bundle,
+				Value:      cachedTx.GetTransaction().Tx.Value,
+			})
+		}
+		cachedTx.Release(true) // tx -1
+	}
+
+	result.Truncated = len(txHashes) >= maxFind
+	result.MilestoneIndex = tangle.GetSolidMilestoneIndex()
+
+	c.JSON(http.StatusOK, result)
+}
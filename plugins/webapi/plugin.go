@@ -57,7 +57,7 @@ func configure(plugin *node.Plugin) {
 
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "User-Agent, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Accept, Origin, Cache-Control, X-Requested-With, X-IOTA-API-Version")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "User-Agent, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, Accept, Origin, Cache-Control, X-Requested-With, X-IOTA-API-Version, Idempotency-Key")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
 
 		if c.Request.Method == "OPTIONS" {
@@ -70,7 +70,22 @@ func configure(plugin *node.Plugin) {
 	api.Use(corsMiddleware)
 
 	// GZIP
-	api.Use(gzip.Gzip(gzip.DefaultCompression))
+	// websocket connections are hijacked and must not be compressed by the middleware
+	gzipMiddleware := gzip.Gzip(gzip.DefaultCompression, gzip.WithExcludedPaths([]string{streamBroadcastRoutePath}))
+	api.Use(func(c *gin.Context) {
+		// the gzip writer buffers the output, so streamed progress would only reach the client at the end
+		if isStreamingCommandRequest(c) {
+			c.Next()
+			return
+		}
+		gzipMiddleware(c)
+	})
+
+	// the request log is added before the basic auth, so unauthorized requests are logged as well
+	configureRequestLog()
+
+	// disabled routes and API commands are not mounted at all
+	configureRouteSwitches()
 
 	// Load allowed remote access to specific HTTP API commands
 	permittedAPIendpoints := config.NodeConfig.GetStringSlice(config.CfgWebAPIPermitRemoteAccess)
@@ -88,6 +103,15 @@ func configure(plugin *node.Plugin) {
 		}
 	}
 
+	configureTagQuotas()
+	configureHealthChecks()
+
+	maxConcurrentPoW := config.NodeConfig.GetInt(config.CfgWebAPIMaxConcurrentPoW)
+	if maxConcurrentPoW < 1 {
+		maxConcurrentPoW = 1
+	}
+	powSemaphore = make(chan struct{}, maxConcurrentPoW)
+
 	// load whitelisted addresses
 	whitelist := append([]string{"127.0.0.1", "::1"}, config.NodeConfig.GetStringSlice(config.CfgWebAPIWhitelistedAddresses)...)
 	for _, entry := range whitelist {
@@ -164,6 +188,21 @@ func configure(plugin *node.Plugin) {
 
 	if !config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
 		webAPIRoute()
+		streamBroadcastRoute()
+		streamMilestonesRoute()
+		streamAddressesRoute()
+		milestoneByIndexRoute()
+		milestoneFundedAddressesRoute()
+		milestoneOutputsRoute()
+		ledgerStateHashRoute()
+		addressTransactionsRoute()
+		milestoneConeStatusRoute()
+		transactionFullRoute()
+		transactionMetadataRoute()
+		transactionsByHashesRoute()
+		transactionHashFromBytesRoute()
+		peersExportRoute()
+		peersImportRoute()
 
 		// only handle spammer api calls if the spammer plugin is enabled
 		if !node.IsSkipped(spammer.PLUGIN) {
@@ -173,7 +212,13 @@ func configure(plugin *node.Plugin) {
 
 	// return error, if route is not there
 	api.NoRoute(func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		c.JSON(http.StatusNotFound, ErrorReturn{Error: "not found", Code: ErrCodeNotFound})
+	})
+
+	// return error, if route is there but the method is not allowed
+	api.HandleMethodNotAllowed = true
+	api.NoMethod(func(c *gin.Context) {
+		c.JSON(http.StatusMethodNotAllowed, ErrorReturn{Error: "method not allowed", Code: ErrCodeMethodNotAllowed})
 	})
 }
 
@@ -182,7 +227,8 @@ func run(_ *node.Plugin) {
 
 	if !config.NodeConfig.GetBool(config.CfgNetAutopeeringRunAsEntryNode) {
 		// Check for features
-		if _, ok := permittedEndpoints["attachtotangle"]; ok {
+		_, implemented := implementedAPIcalls["attachtotangle"]
+		if _, ok := permittedEndpoints["attachtotangle"]; ok && implemented {
 			features = append(features, "RemotePOW")
 		}
 
@@ -191,6 +237,10 @@ func run(_ *node.Plugin) {
 		}
 	}
 
+	runRequestLog()
+	runMilestoneStream()
+	runAddressStream()
+
 	daemon.BackgroundWorker("WebAPI server", func(shutdownSignal <-chan struct{}) {
 		serverShutdownSignal = shutdownSignal
 
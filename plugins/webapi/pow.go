@@ -1,12 +1,16 @@
 package webapi
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/iotaledger/hive.go/node"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/iotaledger/iota.go/consts"
@@ -14,14 +18,78 @@ import (
 	"github.com/iotaledger/iota.go/trinary"
 
 	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/tipselect"
 	"github.com/gohornet/hornet/plugins/curl"
 	"github.com/gohornet/hornet/plugins/pow"
+	"github.com/gohornet/hornet/plugins/urts"
 )
 
 func init() {
 	addEndpoint("attachToTangle", attachToTangle, implementedAPIcalls)
+	addEndpoint("getPoWParameters", getPoWParameters, implementedAPIcalls)
 }
 
+// getPoWParameters returns the PoW difficulty the node accepts for new transactions and whether the node
+// performs the PoW for the caller via attachToTangle.
+// a transaction hash has to end with at least "minWeightMagnitude" zero trits, which takes 3^mwm attempts on average.
+func getPoWParameters(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	mwm := config.NodeConfig.GetInt(config.CfgCoordinatorMWM)
+
+	result := GetPoWParametersReturn{
+		MinWeightMagnitude: mwm,
+		ExpectedAttempts:   math.Pow(3, float64(mwm)),
+	}
+
+	if remotePoWAvailable(c) {
+		result.RemotePoW = true
+		result.PoWType = pow.Handler().GetPoWType()
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+var (
+	// powSemaphore limits the amount of concurrent PoW computations of attachToTangle.
+	// the local PoW already uses all CPU cores, so additional computations only slow down the running ones.
+	powSemaphore chan struct{}
+)
+
+// attachToTanglePoWTimeout returns the PoW timeout of attachToTangle for the requested timeout in seconds (0 = default).
+// a requested timeout is capped by "httpAPI.maxPoWTimeoutSeconds", the returned timeout is 0 if the PoW is unlimited.
+func attachToTanglePoWTimeout(requestedSeconds int) (time.Duration, error) {
+	if requestedSeconds < 0 {
+		return 0, fmt.Errorf("Invalid powTimeoutSeconds: %d", requestedSeconds)
+	}
+
+	timeoutSeconds := config.NodeConfig.GetInt(config.CfgWebAPIPoWTimeoutSeconds)
+	if requestedSeconds > 0 {
+		timeoutSeconds = requestedSeconds
+	}
+
+	if maxTimeoutSeconds := config.NodeConfig.GetInt(config.CfgWebAPIMaxPoWTimeoutSeconds); maxTimeoutSeconds > 0 && (timeoutSeconds == 0 || timeoutSeconds > maxTimeoutSeconds) {
+		timeoutSeconds = maxTimeoutSeconds
+	}
+
+	return time.Duration(timeoutSeconds) * time.Second, nil
+}
+
+// remotePoWAvailable returns whether the caller is allowed to let the node perform the PoW via attachToTangle.
+func remotePoWAvailable(c *gin.Context) bool {
+	if _, implemented := implementedAPIcalls["attachtotangle"]; !implemented {
+		// the command was disabled
+		return false
+	}
+	if _, permitted := permittedEndpoints["attachtotangle"]; permitted {
+		return true
+	}
+	return networkWhitelisted(c)
+}
+
+// attachToTangle chains the given bundle to the given trunk and branch transactions and does the PoW.
+// if "powProvided" is set, the client already chained the bundle and did the PoW, so the transactions are not modified
+// and only their references and nonces are verified.
+// if neither trunk nor branch are given, the node selects the tips itself and returns them alongside the trytes.
 func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	e := ErrorReturn{}
 	query := &AttachToTangle{}
@@ -53,6 +121,58 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
+	powTimeout, err := attachToTanglePoWTimeout(query.PoWTimeoutSeconds)
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	// retries with the same idempotency key get the result of the first request, so the bundle is not attached twice
+	idemKey := idempotencyKey(c, "attachToTangle")
+	requestDigest := idempotencyDigest(append([]string{query.TrunkTransaction, query.BranchTransaction, strconv.Itoa(query.MinWeightMagnitude), strconv.FormatBool(query.PoWProvided)}, query.Trytes...)...)
+	if replayIdempotentResponse(c, idemKey, requestDigest) {
+		return
+	}
+	defer releaseIdempotencyKey(idemKey)
+
+	// let the node select the tips if the client didn't provide any
+	var tipsSelected, tipsUnsafe bool
+	if len(query.TrunkTransaction) == 0 && len(query.BranchTransaction) == 0 && !query.PoWProvided {
+		if node.IsSkipped(urts.PLUGIN) {
+			e.Error = "tipselection plugin disabled in this node"
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+
+		tipsUnsafe = !tangle.IsNodeSyncedWithThreshold()
+
+		tips, _, err := selectNonLazyTipsWithFallback()
+		if err != nil {
+			if err == tangle.ErrNodeNotSynced || err == tipselect.ErrNoTipsAvailable {
+				e.Error = err.Error()
+				c.JSON(http.StatusServiceUnavailable, e)
+				return
+			}
+			e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+
+		query.TrunkTransaction, query.BranchTransaction = tips[0].Trytes(), tips[1].Trytes()
+		tipsSelected = true
+	}
+
+	// the PoW is aborted if the client disconnects or the timeout elapses
+	var powCtx context.Context
+	var powCancel context.CancelFunc
+	if powTimeout > 0 {
+		powCtx, powCancel = context.WithTimeout(c.Request.Context(), powTimeout)
+	} else {
+		powCtx, powCancel = context.WithCancel(c.Request.Context())
+	}
+	defer powCancel()
+
 	txs, err := transaction.AsTransactionObjects(query.Trytes, nil)
 	if err != nil {
 		e.Error = err.Error()
@@ -84,36 +204,54 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	var prev trinary.Hash
 	for i := 0; i < len(txs); i++ {
 
-		switch {
-		case i == 0:
-			txs[i].TrunkTransaction = query.TrunkTransaction
-			txs[i].BranchTransaction = query.BranchTransaction
-		default:
-			txs[i].TrunkTransaction = prev
-			txs[i].BranchTransaction = query.TrunkTransaction
+		trunk, branch := query.TrunkTransaction, query.BranchTransaction
+		if i != 0 {
+			trunk, branch = prev, query.TrunkTransaction
 		}
 
-		txs[i].AttachmentTimestamp = time.Now().UnixNano() / int64(time.Millisecond)
-		txs[i].AttachmentTimestampLowerBound = consts.LowerBoundAttachmentTimestamp
-		txs[i].AttachmentTimestampUpperBound = consts.UpperBoundAttachmentTimestamp
+		if query.PoWProvided {
+			// the transaction must not be modified, otherwise the provided nonce would be invalidated
+			if txs[i].TrunkTransaction != trunk || txs[i].BranchTransaction != branch {
+				e.Error = fmt.Sprintf("Transaction with index %d does not reference the expected trunk %s and branch %s", txs[i].CurrentIndex, trunk, branch)
+				c.JSON(http.StatusBadRequest, e)
+				return
+			}
+		} else {
+			txs[i].TrunkTransaction = trunk
+			txs[i].BranchTransaction = branch
 
-		// Convert tx to trytes
-		trytes, err := transaction.TransactionToTrytes(&txs[i])
-		if err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
-		}
+			txs[i].AttachmentTimestamp = time.Now().UnixNano() / int64(time.Millisecond)
+			txs[i].AttachmentTimestampLowerBound = consts.LowerBoundAttachmentTimestamp
+			txs[i].AttachmentTimestampUpperBound = consts.UpperBoundAttachmentTimestamp
 
-		// Do the PoW
-		ts := time.Now()
-		txs[i].Nonce, err = pow.Handler().DoPoW(trytes, query.MinWeightMagnitude)
-		if err != nil {
-			e.Error = err.Error()
-			c.JSON(http.StatusInternalServerError, e)
-			return
+			// Convert tx to trytes
+			trytes, err := transaction.TransactionToTrytes(&txs[i])
+			if err != nil {
+				e.Error = err.Error()
+				c.JSON(http.StatusInternalServerError, e)
+				return
+			}
+
+			// Do the PoW
+			ts := time.Now()
+			txs[i].Nonce, err = pow.Handler().DoPoWWithContext(powCtx, powSemaphore, trytes, query.MinWeightMagnitude)
+			switch err {
+			case nil:
+			case context.Canceled:
+				// the client disconnected, so there is no one to send a response to
+				return
+			case context.DeadlineExceeded:
+				e.Error = fmt.Sprintf("PoW did not finish within %v, the node is busy, please retry later", powTimeout)
+				e.Code = ErrCodePoWTimeout
+				c.JSON(http.StatusServiceUnavailable, e)
+				return
+			default:
+				e.Error = err.Error()
+				c.JSON(http.StatusInternalServerError, e)
+				return
+			}
+			log.Debugf("PoW method: \"%s\", MWM: %d, took %v", pow.Handler().GetPoWType(), mwm, time.Since(ts).Truncate(time.Millisecond))
 		}
-		log.Debugf("PoW method: \"%s\", MWM: %d, took %v", pow.Handler().GetPoWType(), mwm, time.Since(ts).Truncate(time.Millisecond))
 
 		// Convert tx to trits
 		txTrits, err := transaction.TransactionToTrits(&txs[i])
@@ -137,7 +275,13 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 		// Check tx
 		if !transaction.HasValidNonce(&txs[i], uint64(query.MinWeightMagnitude)) {
-			e.Error = err.Error()
+			if query.PoWProvided {
+				e.Error = fmt.Sprintf("The provided nonce of the transaction with index %d does not satisfy the MinWeightMagnitude %d", txs[i].CurrentIndex, query.MinWeightMagnitude)
+				e.Code = ErrCodeInsufficientPoW
+				c.JSON(http.StatusBadRequest, e)
+				return
+			}
+			e.Error = fmt.Sprintf("Invalid nonce after PoW of the transaction with index %d", txs[i].CurrentIndex)
 			c.JSON(http.StatusInternalServerError, e)
 			return
 		}
@@ -150,5 +294,12 @@ func attachToTangle(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	powedTxTrytes := transaction.MustTransactionsToTrytes(txs)
 
-	c.JSON(http.StatusOK, AttachToTangleReturn{Trytes: powedTxTrytes})
+	result := AttachToTangleReturn{Trytes: powedTxTrytes}
+	if tipsSelected {
+		result.TrunkTransaction, result.BranchTransaction = query.TrunkTransaction, query.BranchTransaction
+		result.Unsafe = tipsUnsafe
+	}
+	storeIdempotentResponse(idemKey, requestDigest, result)
+
+	c.JSON(http.StatusOK, result)
 }
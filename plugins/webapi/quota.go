@@ -0,0 +1,169 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+const (
+	// ErrCodeTagQuotaExceeded is the error code returned if storing the transactions would exceed a tag quota.
+	ErrCodeTagQuotaExceeded = "tag_quota_exceeded"
+
+	// tagQuotaReservationTimeout is the time after which the reservation of an accepted transaction expires,
+	// if the transaction wasn't stored until then and the reservation wasn't released.
+	tagQuotaReservationTimeout = 1 * time.Minute
+)
+
+// tagQuota limits the amount of stored transactions with a tag that starts with the prefix.
+// all transactions have the same size, so the amount of transactions also limits the used storage.
+type tagQuota struct {
+	prefix   trinary.Trytes
+	maxCount int
+	// reserved holds the hashes of accepted transactions which are not stored yet and the time their reservation expires.
+	reserved map[trinary.Hash]time.Time
+}
+
+// tagQuotaReservation holds the transactions reserved per quota by a single request.
+type tagQuotaReservation map[*tagQuota][]trinary.Hash
+
+var (
+	// tagQuotas are sorted by the length of the prefix, the most specific quota first.
+	tagQuotas []*tagQuota
+	// tagQuotasLock makes checking and reserving the quotas atomic, so concurrent broadcasts can't exceed them.
+	tagQuotasLock sync.Mutex
+)
+
+// configureTagQuotas parses the configured tag quotas.
+func configureTagQuotas() {
+	for _, entry := range config.NodeConfig.GetStringSlice(config.CfgWebAPITagQuotas) {
+		quota, err := parseTagQuota(entry)
+		if err != nil {
+			log.Warnf("Invalid tag quota %s: %v", entry, err)
+			continue
+		}
+
+		tagQuotas = append(tagQuotas, quota)
+
+		// the stored transactions are only counted once, afterwards the counter is updated by the tangle storage
+		tangle.RegisterTagPrefixCounter(quota.prefix)
+	}
+
+	sort.Slice(tagQuotas, func(i, j int) bool {
+		return len(tagQuotas[i].prefix) > len(tagQuotas[j].prefix)
+	})
+}
+
+// parseTagQuota parses a tag quota in the format "PREFIX:maxCount".
+func parseTagQuota(entry string) (*tagQuota, error) {
+	parts := strings.Split(entry, ":")
+	if len(parts) != 2 {
+		return nil, errors.New("the format is PREFIX:maxCount")
+	}
+
+	prefix := parts[0]
+	if len(prefix) == 0 || len(prefix) > 27 || trinary.ValidTrytes(prefix) != nil {
+		return nil, errors.New("the prefix must consist of 1 to 27 trytes")
+	}
+
+	maxCount, err := strconv.Atoi(parts[1])
+	if err != nil || maxCount < 0 {
+		return nil, errors.New("the count must be a non-negative integer")
+	}
+
+	return &tagQuota{prefix: prefix, maxCount: maxCount, reserved: make(map[trinary.Hash]time.Time)}, nil
+}
+
+// tagQuotaForTag returns the most specific quota which matches the given tag or nil.
+func tagQuotaForTag(tag trinary.Trytes) *tagQuota {
+	for _, quota := range tagQuotas {
+		if strings.HasPrefix(tag, quota.prefix) {
+			return quota
+		}
+	}
+	return nil
+}
+
+// pruneReservations removes the reservations of transactions which were stored in the meantime,
+// since they are counted by the tag prefix counter, and the expired reservations.
+// tagQuotasLock has to be held by the caller.
+func (quota *tagQuota) pruneReservations(now time.Time) {
+	for txHash, expiration := range quota.reserved {
+		if now.After(expiration) || tangle.ContainsTransaction(hornet.HashFromHashTrytes(txHash)) {
+			delete(quota.reserved, txHash)
+		}
+	}
+}
+
+// reserveTagQuotas checks whether storing the given transactions would exceed one of the configured tag quotas
+// and reserves the quotas for the transactions until they are stored.
+// transactions which are already stored or reserved are not counted twice.
+// the reservation has to be released if the transactions are not handed over to the node afterwards.
+func reserveTagQuotas(txs transaction.Transactions) (tagQuotaReservation, error) {
+	if len(tagQuotas) == 0 {
+		return nil, nil
+	}
+
+	tagQuotasLock.Lock()
+	defer tagQuotasLock.Unlock()
+
+	now := time.Now()
+
+	newTxsPerQuota := make(tagQuotaReservation)
+	for i := range txs {
+		quota := tagQuotaForTag(txs[i].Tag)
+		if quota == nil || tangle.ContainsTransaction(hornet.HashFromHashTrytes(txs[i].Hash)) {
+			continue
+		}
+		if _, reserved := quota.reserved[txs[i].Hash]; reserved {
+			continue
+		}
+		newTxsPerQuota[quota] = append(newTxsPerQuota[quota], txs[i].Hash)
+	}
+
+	for quota, newTxs := range newTxsPerQuota {
+		quota.pruneReservations(now)
+
+		if tangle.TagPrefixCount(quota.prefix)+len(quota.reserved)+len(newTxs) > quota.maxCount {
+			metrics.SharedServerMetrics.RejectedTagQuotaTransactions.Add(uint32(len(newTxs)))
+			return nil, fmt.Errorf("storage quota of %d transactions for tags with prefix %s exceeded", quota.maxCount, quota.prefix)
+		}
+	}
+
+	for quota, newTxs := range newTxsPerQuota {
+		for _, txHash := range newTxs {
+			quota.reserved[txHash] = now.Add(tagQuotaReservationTimeout)
+		}
+	}
+
+	return newTxsPerQuota, nil
+}
+
+// release frees the reserved quotas of transactions which were rejected after the reservation.
+// transactions which were stored in the meantime are counted by the tag prefix counter anyway.
+func (r tagQuotaReservation) release() {
+	if len(r) == 0 {
+		return
+	}
+
+	tagQuotasLock.Lock()
+	defer tagQuotasLock.Unlock()
+
+	for quota, txHashes := range r {
+		for _, txHash := range txHashes {
+			delete(quota.reserved, txHash)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package webapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagQuota(t *testing.T) {
+	quota, err := parseTagQuota("HORNET:100")
+	assert.NoError(t, err)
+	assert.Equal(t, "HORNET", quota.prefix)
+	assert.Equal(t, 100, quota.maxCount)
+
+	quota, err = parseTagQuota("A:0")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, quota.maxCount)
+
+	for _, entry := range []string{
+		"",
+		"HORNET",
+		"HORNET:100:1",
+		":100",
+		"hornet:100",
+		"AAAAAAAAAAAAAAAAAAAAAAAAAAAA:100",
+		"HORNET:",
+		"HORNET:-1",
+		"HORNET:abc",
+	} {
+		_, err := parseTagQuota(entry)
+		assert.Error(t, err, entry)
+	}
+}
@@ -0,0 +1,27 @@
+package webapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/plugins/reattacher"
+)
+
+func init() {
+	addEndpoint("getAutoReattachments", getAutoReattachments, implementedAPIcalls)
+}
+
+// getAutoReattachments returns the bundles which were broadcasted with "autoReattach" and their tracking status.
+func getAutoReattachments(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	trackedBundles, err := reattacher.TrackedBundles()
+	if err != nil {
+		e.Error = err.Error()
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, GetAutoReattachmentsReturn{Bundles: trackedBundles})
+}
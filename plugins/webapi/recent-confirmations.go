@@ -0,0 +1,45 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gohornet/hornet/pkg/config"
+	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
+)
+
+func init() {
+	addEndpoint("getRecentlyConfirmedTransactions", getRecentlyConfirmedTransactions, implementedAPIcalls)
+}
+
+// getRecentlyConfirmedTransactions returns the most recently confirmed transactions, newest first,
+// together with the index of the milestone that confirmed them.
+// it is a cheap polling alternative to the confirmation streams, but transactions are missed
+// if more than "tangle.recentConfirmationsWindow" transactions were confirmed between two requests.
+func getRecentlyConfirmedTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetRecentlyConfirmedTransactions{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	maxRequestsList := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxRequestsList)
+
+	if query.Limit < 0 || query.Limit > maxRequestsList {
+		e.Error = fmt.Sprintf("Invalid limit supplied, max. allowed: %d", maxRequestsList)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = maxRequestsList
+	}
+
+	c.JSON(http.StatusOK, GetRecentlyConfirmedTransactionsReturn{Transactions: tangleplugin.GetRecentConfirmations(query.Limit)})
+}
@@ -0,0 +1,213 @@
+package webapi
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/shutdown"
+)
+
+const (
+	// requestLogCommandKey is the key of the API command in the gin context.
+	requestLogCommandKey = "requestLogCommand"
+	// requestLogRedacted replaces the values of redacted headers.
+	requestLogRedacted = "[REDACTED]"
+)
+
+var (
+	// alwaysRedactedHeaders contains the headers which are never logged in clear text.
+	alwaysRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+	// reqLogger is the request logger, or nil if the request log is disabled.
+	reqLogger *requestLogger
+)
+
+// requestLogEntry is a structured entry of the request log.
+type requestLogEntry struct {
+	Time     string              `json:"time"`
+	Level    string              `json:"level"`
+	Method   string              `json:"method"`
+	Route    string              `json:"route"`
+	Command  string              `json:"command,omitempty"`
+	ClientIP string              `json:"clientIP"`
+	Status   int                 `json:"status"`
+	Latency  int64               `json:"latencyMs"`
+	Headers  map[string][]string `json:"headers"`
+}
+
+// requestLogger logs the requests of the selected routes and API commands.
+type requestLogger struct {
+	level           string
+	routes          map[string]struct{}
+	commands        map[string]struct{}
+	logAll          bool
+	redactedHeaders map[string]struct{}
+
+	// either the requests are written to the file or to the node logger
+	fileLock sync.Mutex
+	file     *os.File
+	log      *logger.Logger
+}
+
+// configureRequestLog adds the request logging middleware if it is enabled.
+// the configured routes are either route templates like "/milestones/:index", API commands like "getNodeInfo" or "*" for all requests.
+func configureRequestLog() {
+	if !config.NodeConfig.GetBool(config.CfgWebAPIRequestLogEnabled) {
+		return
+	}
+
+	reqLogger = &requestLogger{
+		level:           strings.ToLower(config.NodeConfig.GetString(config.CfgWebAPIRequestLogLevel)),
+		routes:          make(map[string]struct{}),
+		commands:        make(map[string]struct{}),
+		redactedHeaders: make(map[string]struct{}),
+	}
+
+	switch reqLogger.level {
+	case "debug", "info", "warn":
+	default:
+		log.Fatalf("'%s' must be one of [debug, info, warn], got '%s'", config.CfgWebAPIRequestLogLevel, reqLogger.level)
+	}
+
+	for _, route := range config.NodeConfig.GetStringSlice(config.CfgWebAPIRequestLogRoutes) {
+		switch {
+		case route == "*":
+			reqLogger.logAll = true
+		case strings.HasPrefix(route, "/"):
+			reqLogger.routes[route] = struct{}{}
+		default:
+			reqLogger.commands[strings.ToLower(route)] = struct{}{}
+		}
+	}
+
+	for _, header := range append(alwaysRedactedHeaders, config.NodeConfig.GetStringSlice(config.CfgWebAPIRequestLogRedactedHeaders)...) {
+		reqLogger.redactedHeaders[http.CanonicalHeaderKey(header)] = struct{}{}
+	}
+
+	if outputPath := config.NodeConfig.GetString(config.CfgWebAPIRequestLogOutputPath); outputPath != "" {
+		file, err := os.OpenFile(outputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			log.Fatalf("unable to open the request log '%s': %s", outputPath, err)
+		}
+		reqLogger.file = file
+	} else {
+		reqLogger.log = log.Named("RequestLog")
+	}
+
+	api.Use(reqLogger.middleware)
+}
+
+// runRequestLog closes the request log file on shutdown.
+func runRequestLog() {
+	if reqLogger == nil || reqLogger.file == nil {
+		return
+	}
+
+	daemon.BackgroundWorker("WebAPI[RequestLog]", func(shutdownSignal <-chan struct{}) {
+		<-shutdownSignal
+
+		reqLogger.fileLock.Lock()
+		defer reqLogger.fileLock.Unlock()
+
+		if err := reqLogger.file.Close(); err != nil {
+			log.Warnf("unable to close the request log: %s", err)
+		}
+		reqLogger.file = nil
+	}, shutdown.PriorityAPI)
+}
+
+// middleware logs the request after it was handled, if the route or the API command was selected.
+func (l *requestLogger) middleware(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	route := c.FullPath()
+	command := c.GetString(requestLogCommandKey)
+	if !l.shouldLog(route, command) {
+		return
+	}
+
+	// the address of the connection is used instead of forwarding headers, since those can be set by the client
+	clientIP, _, _ := net.SplitHostPort(c.Request.RemoteAddr)
+
+	l.write(&requestLogEntry{
+		Time:     start.UTC().Format(time.RFC3339),
+		Level:    l.level,
+		Method:   c.Request.Method,
+		Route:    route,
+		Command:  command,
+		ClientIP: clientIP,
+		Status:   c.Writer.Status(),
+		Latency:  time.Since(start).Milliseconds(),
+		Headers:  l.redact(c.Request.Header),
+	})
+}
+
+func (l *requestLogger) shouldLog(route string, command string) bool {
+	if l.logAll {
+		return true
+	}
+	if _, exists := l.routes[route]; exists {
+		return true
+	}
+	_, exists := l.commands[strings.ToLower(command)]
+	return exists
+}
+
+// redact returns a copy of the headers in which the values of sensitive headers are replaced.
+func (l *requestLogger) redact(headers http.Header) map[string][]string {
+	result := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if _, redacted := l.redactedHeaders[http.CanonicalHeaderKey(key)]; redacted {
+			result[key] = []string{requestLogRedacted}
+			continue
+		}
+		result[key] = values
+	}
+	return result
+}
+
+func (l *requestLogger) write(entry *requestLogEntry) {
+	if l.log != nil {
+		fields := []interface{}{"method", entry.Method, "route", entry.Route, "command", entry.Command, "clientIP", entry.ClientIP, "status", entry.Status, "latencyMs", entry.Latency, "headers", entry.Headers}
+		switch l.level {
+		case "debug":
+			l.log.Debugw("request", fields...)
+		case "warn":
+			l.log.Warnw("request", fields...)
+		default:
+			l.log.Infow("request", fields...)
+		}
+		return
+	}
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		log.Warnf("unable to marshal request log entry: %s", err)
+		return
+	}
+
+	l.fileLock.Lock()
+	defer l.fileLock.Unlock()
+
+	if l.file == nil {
+		// the request log was already closed
+		return
+	}
+
+	if _, err := l.file.Write(append(entryJSON, '\n')); err != nil {
+		log.Warnf("unable to write request log entry: %s", err)
+	}
+}
@@ -0,0 +1,83 @@
+package webapi
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/config"
+)
+
+var (
+	enabledRoutePatterns  []string
+	disabledRoutePatterns []string
+)
+
+// configureRouteSwitches loads the enabled and disabled routes and removes the disabled API commands,
+// so they are answered like unknown commands.
+//
+// entries starting with "/" match the route templates as they are registered (e.g. "/milestones/:index"),
+// all other entries match API commands case-insensitively (e.g. "createSnapshotFile").
+// an entry ending with "*" matches every route or command with the given prefix, e.g. "/milestones/*" or "get*",
+// "*" alone matches all routes and commands.
+// if enabled entries are given, only the matching routes and commands are mounted.
+// disabled entries always take precedence over enabled ones.
+func configureRouteSwitches() {
+	enabledRoutePatterns = config.NodeConfig.GetStringSlice(config.CfgWebAPIEnabledRoutes)
+	disabledRoutePatterns = config.NodeConfig.GetStringSlice(config.CfgWebAPIDisabledRoutes)
+
+	for cmd := range implementedAPIcalls {
+		if !routeEnabled(cmd) {
+			delete(implementedAPIcalls, cmd)
+		}
+	}
+}
+
+// routeEnabled checks whether the given route template or API command may be mounted.
+func routeEnabled(route string) bool {
+	if len(enabledRoutePatterns) > 0 && !matchesAnyRoutePattern(enabledRoutePatterns, route) {
+		return false
+	}
+	return !matchesAnyRoutePattern(disabledRoutePatterns, route)
+}
+
+func matchesAnyRoutePattern(patterns []string, route string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+
+		isRoute := strings.HasPrefix(route, "/")
+		if strings.HasPrefix(pattern, "/") != isRoute {
+			// route patterns never match commands and vice versa
+			continue
+		}
+
+		if !isRoute {
+			pattern = strings.ToLower(pattern)
+			route = strings.ToLower(route)
+		}
+
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(route, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+			continue
+		}
+
+		if pattern == route {
+			return true
+		}
+	}
+	return false
+}
+
+// mountRoute registers the handler for the given route, if the route is not disabled.
+// disabled routes are answered with the same error as unknown routes.
+func mountRoute(method string, route string, handler gin.HandlerFunc) {
+	if !routeEnabled(route) {
+		log.Infof("Route %s %s is disabled", method, route)
+		return
+	}
+	api.Handle(method, route, handler)
+}
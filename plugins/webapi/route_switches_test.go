@@ -0,0 +1,67 @@
+package webapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesAnyRoutePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		route    string
+		matches  bool
+	}{
+		{"no patterns", nil, "/milestones/:index", false},
+		{"exact route", []string{"/milestones/:index"}, "/milestones/:index", true},
+		{"different route", []string{"/milestones/:index"}, "/transactions/:hash", false},
+		{"route prefix wildcard", []string{"/milestones/*"}, "/milestones/:index", true},
+		{"route prefix wildcard without match", []string{"/milestones/*"}, "/ledger/state-hash", false},
+		{"routes are case-sensitive", []string{"/Milestones/:index"}, "/milestones/:index", false},
+		{"exact command", []string{"createSnapshotFile"}, "createSnapshotFile", true},
+		{"commands are case-insensitive", []string{"CREATESNAPSHOTFILE"}, "createsnapshotfile", true},
+		{"command prefix wildcard", []string{"get*"}, "getNodeInfo", true},
+		{"command prefix wildcard is case-insensitive", []string{"GET*"}, "getnodeinfo", true},
+		{"command prefix wildcard without match", []string{"get*"}, "attachToTangle", false},
+		{"wildcard matches routes", []string{"*"}, "/milestones/:index", true},
+		{"wildcard matches commands", []string{"*"}, "attachToTangle", true},
+		{"route patterns never match commands", []string{"/*"}, "getNodeInfo", false},
+		{"command patterns never match routes", []string{"milestones*"}, "/milestones/:index", false},
+		{"any pattern matches", []string{"getNodeInfo", "/milestones/*"}, "/milestones/:index", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.matches, matchesAnyRoutePattern(test.patterns, test.route))
+		})
+	}
+}
+
+func TestRouteEnabled(t *testing.T) {
+	defer func() {
+		enabledRoutePatterns, disabledRoutePatterns = nil, nil
+	}()
+
+	tests := []struct {
+		name     string
+		enabled  []string
+		disabled []string
+		route    string
+		result   bool
+	}{
+		{"everything is enabled by default", nil, nil, "/milestones/:index", true},
+		{"disabled route", nil, []string{"/milestones/*"}, "/milestones/:index", false},
+		{"only enabled routes are mounted", []string{"/ledger/*"}, nil, "/milestones/:index", false},
+		{"enabled route", []string{"/milestones/*"}, nil, "/milestones/:index", true},
+		{"disabled beats enabled", []string{"/milestones/*"}, []string{"/milestones/:index"}, "/milestones/:index", false},
+		{"disabled wildcard beats enabled command", []string{"getNodeInfo"}, []string{"*"}, "getNodeInfo", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enabledRoutePatterns, disabledRoutePatterns = test.enabled, test.disabled
+			assert.Equal(t, test.result, routeEnabled(test.route))
+		})
+	}
+}
@@ -1,22 +1,66 @@
 package webapi
 
 import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/plugins/snapshot"
 )
 
 func init() {
 	addEndpoint("createSnapshotFile", createSnapshotFile, implementedAPIcalls)
+	addEndpoint("getCheckpoint", getCheckpoint, implementedAPIcalls)
+	addEndpoint("getSolidEntryPointsCount", getSolidEntryPointsCount, implementedAPIcalls)
 }
 
+const (
+	// maxStreamingCommandPeekBytes is the maximum amount of bytes of a request body which are inspected to detect streaming commands.
+	maxStreamingCommandPeekBytes = 4096
+)
+
+// isStreamingCommandRequest checks whether the request is a createSnapshotFile call with streamed progress.
+// the inspected part of the body is put back, so the request is parsed by the API route as usual.
+func isStreamingCommandRequest(c *gin.Context) bool {
+	if c.Request.Method != http.MethodPost || c.FullPath() != "/" || c.Request.Body == nil {
+		return false
+	}
+
+	peeked, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, maxStreamingCommandPeekBytes))
+	c.Request.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(peeked), c.Request.Body))
+	if err != nil || !bytes.Contains(bytes.ToLower(peeked), []byte("createsnapshotfile")) {
+		return false
+	}
+
+	request := &struct {
+		Command string `json:"command"`
+		Stream  bool   `json:"stream"`
+	}{}
+	if err := json.Unmarshal(peeked, request); err != nil {
+		return false
+	}
+
+	return strings.EqualFold(request.Command, "createSnapshotFile") && request.Stream
+}
+
+// createSnapshotFile creates a snapshot file for the given target index.
+// the creation is aborted and the partially written file is removed if the client disconnects
+// or the configured maximum duration elapses.
+// if "stream" is set, the progress of the creation is written as newline delimited JSON objects.
 func createSnapshotFile(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
 	e := ErrorReturn{}
 	query := &CreateSnapshotFile{}
@@ -29,11 +73,163 @@ func createSnapshotFile(i interface{}, c *gin.Context, abortSignal <-chan struct
 
 	snapshotFilePath := filepath.Join(filepath.Dir(config.NodeConfig.GetString(config.CfgLocalSnapshotsPath)), fmt.Sprintf("export_%d.bin", query.TargetIndex))
 
-	if err := snapshot.CreateLocalSnapshot(milestone.Index(query.TargetIndex), snapshotFilePath, false, abortSignal); err != nil {
+	timeout := time.Duration(config.NodeConfig.GetInt(config.CfgWebAPICreateSnapshotTimeoutSeconds)) * time.Second
+	var timeoutChan <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+
+	// the snapshot creation is aborted on shutdown, if the client disconnects or if the timeout elapses
+	snapshotAbortSignal := make(chan struct{})
+	creationDone := make(chan struct{})
+	timedOut := make(chan struct{})
+	streamFailed := make(chan struct{})
+	go func() {
+		select {
+		case <-abortSignal:
+		case <-c.Request.Context().Done():
+		case <-streamFailed:
+		case <-timeoutChan:
+			close(timedOut)
+		case <-creationDone:
+			return
+		}
+		close(snapshotAbortSignal)
+	}()
+
+	var progress snapshot.SnapshotProgressFunc
+	if query.Stream {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+
+		encoder := json.NewEncoder(c.Writer)
+		var streamFailedOnce sync.Once
+		progress = func(stage snapshot.SnapshotStage, msIndex milestone.Index) {
+			if err := encoder.Encode(&CreateSnapshotFileProgress{Stage: string(stage), MilestoneIndex: msIndex}); err != nil {
+				// the client is gone, abort the snapshot creation
+				streamFailedOnce.Do(func() { close(streamFailed) })
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+
+	err := snapshot.CreateLocalSnapshot(milestone.Index(query.TargetIndex), snapshotFilePath, false, snapshotAbortSignal, progress)
+	close(creationDone)
+
+	if err != nil {
+		status := http.StatusInternalServerError
+		select {
+		case <-timedOut:
+			status = http.StatusServiceUnavailable
+			err = fmt.Errorf("snapshot creation exceeded the maximum duration of %v: %w", timeout, err)
+		default:
+		}
+
+		if query.Stream {
+			json.NewEncoder(c.Writer).Encode(&CreateSnapshotFileProgress{Done: true, Error: err.Error()})
+			return
+		}
+
 		e.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, e)
+		c.JSON(status, e)
+		return
+	}
+
+	if query.Stream {
+		json.NewEncoder(c.Writer).Encode(&CreateSnapshotFileProgress{Done: true})
 		return
 	}
 
 	c.JSON(http.StatusOK, CreateSnapshotFileReturn{})
 }
+
+// getCheckpoint returns a compact checkpoint of the given milestone, which can be used to initialize a light node.
+// the checkpoint is not signed by the node, so the light node has to trust this node to deliver a correct ledger state hash.
+// the milestone itself can be verified by the light node with the signature of the coordinator.
+func getCheckpoint(i interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &GetCheckpoint{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	smi := tangle.GetSolidMilestoneIndex()
+	if query.MilestoneIndex == 0 {
+		query.MilestoneIndex = smi
+	}
+
+	if query.MilestoneIndex > smi {
+		e.Error = fmt.Sprintf("Invalid milestone index supplied, lsmi is %d", smi)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil && query.MilestoneIndex <= snapshotInfo.PruningIndex {
+		e.Error = fmt.Sprintf("Invalid milestone index supplied, pruning index is %d", snapshotInfo.PruningIndex)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	cachedMs := tangle.GetMilestoneOrNil(query.MilestoneIndex) // bundle +1
+	if cachedMs == nil {
+		e.Error = fmt.Sprintf("Milestone %d not found", query.MilestoneIndex)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+	defer cachedMs.Release(true) // bundle -1
+
+	merkleTreeHash, err := cachedMs.GetBundle().GetMilestoneMerkleTreeHash()
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	cachedMsTailTx := cachedMs.GetBundle().GetTail() // tx +1
+	msTimestamp := cachedMsTailTx.GetTransaction().GetTimestamp()
+	cachedMsTailTx.Release(true) // tx -1
+
+	balances, ledgerIndex, err := tangle.GetLedgerStateForMilestone(query.MilestoneIndex, abortSignal)
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	ledgerStateHash, err := tangle.ComputeLedgerStateHash(balances)
+	if err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, GetCheckpointReturn{
+		MilestoneIndex:          ledgerIndex,
+		MilestoneHash:           cachedMs.GetBundle().GetMilestoneHash().Trytes(),
+		MilestoneTimestamp:      msTimestamp,
+		MilestoneMerkleTreeHash: hex.EncodeToString(merkleTreeHash),
+		CoordinatorAddress:      config.NodeConfig.GetString(config.CfgCoordinatorAddress),
+		LedgerStateHash:         hex.EncodeToString(ledgerStateHash),
+		LedgerAddressesCount:    len(balances),
+	})
+}
+
+// getSolidEntryPointsCount returns the amount of solid entry points and the entry point index of the snapshot info.
+// it only holds the read lock of the solid entry points and doesn't enumerate them, so it is cheap enough for health checks.
+func getSolidEntryPointsCount(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	result := GetSolidEntryPointsCountReturn{
+		Count: tangle.SolidEntryPointsCount(),
+	}
+
+	if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil {
+		result.EntryPointIndex = snapshotInfo.EntryPointIndex
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func spammerRoute() {
-	api.GET("/spammer", func(c *gin.Context) {
+	mountRoute(http.MethodGet, "/spammer", func(c *gin.Context) {
 
 		if !networkWhitelisted(c) {
 			// network is not whitelisted, check if the route is permitted, otherwise deny it.
@@ -0,0 +1,183 @@
+package webapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/metrics"
+	"github.com/gohornet/hornet/plugins/gossip"
+)
+
+const (
+	streamBroadcastRoutePath = "/stream/broadcast"
+	streamWriteTimeout       = 5 * time.Second
+)
+
+var (
+	streamUpgrader = &websocket.Upgrader{
+		HandshakeTimeout: streamWriteTimeout,
+		CheckOrigin:      func(r *http.Request) bool { return true }, // allow any origin, same as the CORS settings of the API
+	}
+
+	// streamBroadcastWorkers limits the amount of submissions which are processed in parallel across all connections.
+	streamBroadcastWorkers chan struct{}
+)
+
+// streamBroadcastRoute handles a websocket connection on which the client pushes bundles to broadcast
+// and receives the result of every submission as soon as it was processed.
+// at most "httpAPI.streamBroadcast.maxInFlight" submissions are processed in parallel per connection,
+// if the pipeline is full, no further submissions are read from the connection until a slot is free again.
+// the submissions of all connections share "httpAPI.streamBroadcast.workers" workers,
+// so a connection with a full pipeline also stops reading if the node is busy with the submissions of other connections.
+// the results are not necessarily sent in the order of the submissions, the client has to match them by ID.
+// new connections are rejected if the limits of "httpAPI.streams.maxSubscribers" are reached.
+func streamBroadcastRoute() {
+	workers := config.NodeConfig.GetInt(config.CfgWebAPIStreamBroadcastWorkers)
+	if workers < 1 {
+		workers = 1
+	}
+	streamBroadcastWorkers = make(chan struct{}, workers)
+
+	mountRoute(http.MethodGet, streamBroadcastRoutePath, func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["stream/broadcast"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [stream/broadcast] is protected"})
+				return
+			}
+		}
+
+		if err := acquireStreamSubscriber(streamBroadcastRoutePath); err != nil {
+			c.JSON(http.StatusServiceUnavailable, ErrorReturn{Error: err.Error(), Code: ErrCodeTooManySubscribers})
+			return
+		}
+		defer releaseStreamSubscriber(streamBroadcastRoutePath)
+
+		conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// the upgrader already replied with an error
+			return
+		}
+
+		serveBroadcastStream(conn, config.NodeConfig.GetInt(config.CfgWebAPIStreamBroadcastMaxInFlight), remotePoWAvailable(c))
+	})
+}
+
+func serveBroadcastStream(conn *websocket.Conn, maxInFlight int, remotePoW bool) {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	results := make(chan *StreamBroadcastResult, maxInFlight)
+	inFlight := make(chan struct{}, maxInFlight)
+	connClosed := make(chan struct{})
+
+	// close the connection on shutdown, since the HTTP server doesn't close hijacked connections
+	go func() {
+		select {
+		case <-serverShutdownSignal:
+			conn.Close()
+		case <-connClosed:
+		}
+	}()
+
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		for result := range results {
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(result); err != nil {
+				// unblock the reader, the remaining results are drained
+				conn.Close()
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for {
+		// back-pressure: only read the next submission if there is a free slot in the pipeline
+		inFlight <- struct{}{}
+
+		request := &StreamBroadcastRequest{}
+		if err := conn.ReadJSON(request); err != nil {
+			<-inFlight
+			break
+		}
+
+		wg.Add(1)
+		go func(request *StreamBroadcastRequest) {
+			defer wg.Done()
+
+			metrics.SharedServerMetrics.StreamBroadcastQueueDepth.Inc()
+			streamBroadcastWorkers <- struct{}{}
+			metrics.SharedServerMetrics.StreamBroadcastQueueDepth.Dec()
+
+			result := processStreamBroadcastRequest(request, remotePoW)
+			result.QueueDepth = metrics.SharedServerMetrics.StreamBroadcastQueueDepth.Load()
+			<-streamBroadcastWorkers
+
+			results <- result
+			<-inFlight
+		}(request)
+	}
+
+	wg.Wait()
+	close(results)
+	<-writerDone
+	close(connClosed)
+	conn.Close()
+}
+
+// processStreamBroadcastRequest validates and broadcasts the transactions of the request like broadcastTransactions.
+// only the first validation problem is returned to the client.
+func processStreamBroadcastRequest(request *StreamBroadcastRequest, remotePoW bool) *StreamBroadcastResult {
+	result := &StreamBroadcastResult{ID: request.ID}
+
+	if len(request.Trytes) == 0 {
+		result.Error = "No trytes provided"
+		return result
+	}
+
+	txs, problems := validateBroadcastTransactions(&BroadcastTransactions{Trytes: request.Trytes}, remotePoW)
+	if len(problems) > 0 {
+		result.Error = problems[0].problem.Message
+		result.Code = problems[0].problem.Code
+		return result
+	}
+
+	if err := checkConnectedPeers(); err != nil {
+		result.Error = err.Error()
+		result.Code = ErrCodeNotEnoughPeers
+		return result
+	}
+
+	reservation, problem := reserveBroadcastTagQuotas(txs)
+	if problem != nil {
+		result.Error = problem.problem.Message
+		result.Code = problem.problem.Code
+		return result
+	}
+
+	for _, trytes := range request.Trytes {
+		if err := gossip.Processor().ValidateTransactionTrytesAndEmit(trytes); err != nil {
+			reservation.release()
+			result.Error = err.Error()
+			return result
+		}
+	}
+
+	result.TxHashes = make([]trinary.Hash, len(txs))
+	for i := range txs {
+		result.TxHashes[i] = txs[i].Hash
+	}
+
+	return result
+}
@@ -0,0 +1,288 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	"github.com/gohornet/hornet/pkg/whiteflag"
+	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
+)
+
+const (
+	streamAddressesRoutePath = "/stream/addresses"
+
+	// StreamAddressEventCreated is the type of an address event if funds were transferred to the address.
+	StreamAddressEventCreated = "created"
+	// StreamAddressEventSpent is the type of an address event if funds were spent from the address.
+	StreamAddressEventSpent = "spent"
+)
+
+var (
+	addressStreamSubscribersLock sync.RWMutex
+	addressStreamSubscribers     = make(map[*addressStreamSubscriber]struct{})
+)
+
+// addressStreamSubscriber is a connection of the address stream.
+type addressStreamSubscriber struct {
+	// the subscribed addresses (without checksum).
+	addresses     map[trinary.Hash]struct{}
+	addressesLock sync.RWMutex
+	// the events which were not sent to the client yet.
+	events chan *StreamAddressEvent
+	// closed if the client doesn't keep up with the events.
+	overflow     chan struct{}
+	overflowOnce sync.Once
+}
+
+// update applies the given subscription changes, it fails if the result would exceed the given maximum.
+func (s *addressStreamSubscriber) update(subscribe []trinary.Hash, unsubscribe []trinary.Hash, maxAddresses int) error {
+	s.addressesLock.Lock()
+	defer s.addressesLock.Unlock()
+
+	for _, addr := range unsubscribe {
+		delete(s.addresses, addr)
+	}
+
+	for _, addr := range subscribe {
+		if _, exists := s.addresses[addr]; exists {
+			continue
+		}
+		if maxAddresses > 0 && len(s.addresses) >= maxAddresses {
+			return fmt.Errorf("too many addresses, max. allowed: %d", maxAddresses)
+		}
+		s.addresses[addr] = struct{}{}
+	}
+
+	return nil
+}
+
+// isSubscribed returns whether the subscriber is subscribed to the given address (without checksum).
+func (s *addressStreamSubscriber) isSubscribed(addr trinary.Hash) bool {
+	s.addressesLock.RLock()
+	defer s.addressesLock.RUnlock()
+
+	_, subscribed := s.addresses[addr]
+	return subscribed
+}
+
+// streamAddressesRoute handles a websocket connection on which the client receives an event for every transaction
+// of a confirmed milestone which transfers funds to or spends funds from one of the subscribed addresses.
+// the initial addresses are passed as "address" query parameters, afterwards the client can change the subscription
+// by sending StreamAddressSubscription messages. at most "httpAPI.streams.maxAddressesPerSubscriber" addresses can be subscribed.
+// the connection is closed with a close frame if an address is malformed or the subscription limit is exceeded.
+// the events are derived from the ledger mutations of every confirmed milestone, so every transfer is sent exactly once.
+func streamAddressesRoute() {
+	mountRoute(http.MethodGet, streamAddressesRoutePath, func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["stream/addresses"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [stream/addresses] is protected"})
+				return
+			}
+		}
+
+		if err := acquireStreamSubscriber(streamAddressesRoutePath); err != nil {
+			c.JSON(http.StatusServiceUnavailable, ErrorReturn{Error: err.Error(), Code: ErrCodeTooManySubscribers})
+			return
+		}
+		defer releaseStreamSubscriber(streamAddressesRoutePath)
+
+		conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// the upgrader already replied with an error
+			return
+		}
+
+		serveAddressStream(conn, c.QueryArray("address"))
+	})
+}
+
+func serveAddressStream(conn *websocket.Conn, initialAddresses []trinary.Hash) {
+	defer conn.Close()
+
+	sendBufferSize := config.NodeConfig.GetInt(config.CfgWebAPIStreamsSendBufferSize)
+	if sendBufferSize < 1 {
+		sendBufferSize = 1
+	}
+	maxAddresses := config.NodeConfig.GetInt(config.CfgWebAPIStreamsMaxAddressesPerSubscriber)
+
+	subscriber := &addressStreamSubscriber{
+		addresses: make(map[trinary.Hash]struct{}),
+		events:    make(chan *StreamAddressEvent, sendBufferSize),
+		overflow:  make(chan struct{}),
+	}
+
+	addresses, err := normalizeStreamAddresses(initialAddresses)
+	if err == nil {
+		err = subscriber.update(addresses, nil, maxAddresses)
+	}
+	if err != nil {
+		writeStreamClose(conn, websocket.ClosePolicyViolation, err.Error())
+		return
+	}
+
+	addressStreamSubscribersLock.Lock()
+	addressStreamSubscribers[subscriber] = struct{}{}
+	addressStreamSubscribersLock.Unlock()
+
+	defer func() {
+		addressStreamSubscribersLock.Lock()
+		delete(addressStreamSubscribers, subscriber)
+		addressStreamSubscribersLock.Unlock()
+	}()
+
+	// read the subscription changes of the client until the connection is closed
+	connClosed := make(chan struct{})
+	go func() {
+		defer close(connClosed)
+		for {
+			subscription := &StreamAddressSubscription{}
+			if err := conn.ReadJSON(subscription); err != nil {
+				return
+			}
+
+			subscribe, err := normalizeStreamAddresses(subscription.Subscribe)
+			if err != nil {
+				writeStreamClose(conn, websocket.ClosePolicyViolation, err.Error())
+				return
+			}
+			unsubscribe, err := normalizeStreamAddresses(subscription.Unsubscribe)
+			if err != nil {
+				writeStreamClose(conn, websocket.ClosePolicyViolation, err.Error())
+				return
+			}
+
+			if err := subscriber.update(subscribe, unsubscribe, maxAddresses); err != nil {
+				writeStreamClose(conn, websocket.ClosePolicyViolation, err.Error())
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-serverShutdownSignal:
+			writeStreamClose(conn, websocket.CloseGoingAway, "node is shutting down")
+			return
+
+		case <-connClosed:
+			return
+
+		case <-subscriber.overflow:
+			writeStreamClose(conn, websocket.ClosePolicyViolation, "send buffer full, the client doesn't keep up with the events")
+			return
+
+		case event := <-subscriber.events:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// normalizeStreamAddresses validates the given addresses and removes their checksums.
+func normalizeStreamAddresses(addresses []trinary.Hash) ([]trinary.Hash, error) {
+	result := make([]trinary.Hash, 0, len(addresses))
+	for _, addr := range addresses {
+		if err := address.ValidAddress(addr); err != nil {
+			return nil, fmt.Errorf("invalid address: %s", addr)
+		}
+		result = append(result, addr[:consts.HashTrytesSize])
+	}
+	return result, nil
+}
+
+// runAddressStream publishes the transfers of every confirmed milestone to the subscribers of the affected addresses.
+func runAddressStream() {
+
+	onMilestoneConfirmed := events.NewClosure(func(confirmation *whiteflag.Confirmation) {
+		addressStreamSubscribersLock.RLock()
+		defer addressStreamSubscribersLock.RUnlock()
+
+		if len(addressStreamSubscribers) == 0 {
+			return
+		}
+
+		// only the addresses which were mutated by the milestone and are subscribed by at least one subscriber are of interest
+		subscribersByAddress := make(map[trinary.Hash][]*addressStreamSubscriber)
+		for addr := range confirmation.Mutations.AddressMutations {
+			addrTrytes := hornet.Hash(addr).Trytes()
+			for subscriber := range addressStreamSubscribers {
+				if subscriber.isSubscribed(addrTrytes) {
+					subscribersByAddress[addrTrytes] = append(subscribersByAddress[addrTrytes], subscriber)
+				}
+			}
+		}
+
+		if len(subscribersByAddress) == 0 {
+			return
+		}
+
+		for _, tailTxHash := range confirmation.Mutations.TailsIncluded {
+			cachedBndl := tangle.GetCachedBundleOrNil(tailTxHash) // bundle +1
+			if cachedBndl == nil {
+				continue
+			}
+
+			cachedTxs := cachedBndl.GetBundle().GetTransactions() // tx +1
+			for _, cachedTx := range cachedTxs {
+				tx := cachedTx.GetTransaction()
+				if tx.Tx.Value == 0 {
+					continue
+				}
+
+				subscribers, subscribed := subscribersByAddress[tx.Tx.Address]
+				if !subscribed {
+					continue
+				}
+
+				event := &StreamAddressEvent{
+					MilestoneIndex: confirmation.MilestoneIndex,
+					Address:        tx.Tx.Address,
+					TxHash:         tx.Tx.Hash,
+					TailTxHash:     tailTxHash.Trytes(),
+					Type:           StreamAddressEventCreated,
+					Amount:         uint64(tx.Tx.Value),
+				}
+				if tx.Tx.Value < 0 {
+					event.Type = StreamAddressEventSpent
+					event.Amount = uint64(-tx.Tx.Value)
+				}
+
+				for _, subscriber := range subscribers {
+					select {
+					case subscriber.events <- event:
+					default:
+						// never block the confirmation because of a slow client
+						subscriber.overflowOnce.Do(func() { close(subscriber.overflow) })
+					}
+				}
+			}
+			cachedTxs.Release(true)  // tx -1
+			cachedBndl.Release(true) // bundle -1
+		}
+	})
+
+	daemon.BackgroundWorker("WebAPI[AddressStream]", func(shutdownSignal <-chan struct{}) {
+		tangleplugin.Events.MilestoneConfirmed.Attach(onMilestoneConfirmed)
+		<-shutdownSignal
+		tangleplugin.Events.MilestoneConfirmed.Detach(onMilestoneConfirmed)
+	}, shutdown.PriorityAPI)
+}
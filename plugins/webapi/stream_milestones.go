@@ -0,0 +1,182 @@
+package webapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+	"github.com/gohornet/hornet/pkg/shutdown"
+	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
+)
+
+const (
+	streamMilestonesRoutePath = "/stream/milestones"
+)
+
+var (
+	milestoneStreamSubscribersLock sync.RWMutex
+	milestoneStreamSubscribers     = make(map[*milestoneStreamSubscriber]struct{})
+)
+
+// milestoneStreamSubscriber is a connection of the milestone stream.
+type milestoneStreamSubscriber struct {
+	// the milestones which were not sent to the client yet.
+	milestones chan *StreamMilestone
+	// closed if the client doesn't keep up with the milestones.
+	overflow     chan struct{}
+	overflowOnce sync.Once
+}
+
+// streamMilestonesRoute handles a websocket connection on which every new solid milestone is pushed to the client.
+// the milestones which were not sent yet are buffered up to "httpAPI.streams.sendBufferSize" per connection,
+// if the client doesn't keep up, the connection is closed instead of buffering further milestones.
+// new connections are rejected if the limits of "httpAPI.streams.maxSubscribers" are reached.
+func streamMilestonesRoute() {
+	mountRoute(http.MethodGet, streamMilestonesRoutePath, func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["stream/milestones"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [stream/milestones] is protected"})
+				return
+			}
+		}
+
+		if err := acquireStreamSubscriber(streamMilestonesRoutePath); err != nil {
+			c.JSON(http.StatusServiceUnavailable, ErrorReturn{Error: err.Error(), Code: ErrCodeTooManySubscribers})
+			return
+		}
+		defer releaseStreamSubscriber(streamMilestonesRoutePath)
+
+		conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// the upgrader already replied with an error
+			return
+		}
+
+		serveMilestoneStream(conn, config.NodeConfig.GetInt(config.CfgWebAPIStreamsSendBufferSize))
+	})
+}
+
+func serveMilestoneStream(conn *websocket.Conn, sendBufferSize int) {
+	if sendBufferSize < 1 {
+		sendBufferSize = 1
+	}
+
+	subscriber := &milestoneStreamSubscriber{
+		milestones: make(chan *StreamMilestone, sendBufferSize),
+		overflow:   make(chan struct{}),
+	}
+
+	milestoneStreamSubscribersLock.Lock()
+	milestoneStreamSubscribers[subscriber] = struct{}{}
+	milestoneStreamSubscribersLock.Unlock()
+
+	defer func() {
+		milestoneStreamSubscribersLock.Lock()
+		delete(milestoneStreamSubscribers, subscriber)
+		milestoneStreamSubscribersLock.Unlock()
+
+		conn.Close()
+	}()
+
+	// the client is not expected to send anything, but the connection has to be read to notice a disconnect
+	connClosed := make(chan struct{})
+	go func() {
+		defer close(connClosed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-serverShutdownSignal:
+			writeStreamClose(conn, websocket.CloseGoingAway, "node is shutting down")
+			return
+
+		case <-connClosed:
+			return
+
+		case <-subscriber.overflow:
+			writeStreamClose(conn, websocket.ClosePolicyViolation, "send buffer full, the client doesn't keep up with the milestones")
+			return
+
+		case ms := <-subscriber.milestones:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(ms); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeStreamClose sends a close frame with the given code and reason to the client.
+func writeStreamClose(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(streamWriteTimeout))
+}
+
+// runMilestoneStream publishes every new solid milestone to the subscribers of the milestone stream.
+func runMilestoneStream() {
+
+	onSolidMilestoneIndexChanged := events.NewClosure(func(msIndex milestone.Index) {
+		milestoneStreamSubscribersLock.RLock()
+		defer milestoneStreamSubscribersLock.RUnlock()
+
+		if len(milestoneStreamSubscribers) == 0 {
+			return
+		}
+
+		ms := newStreamMilestone(msIndex)
+		if ms == nil {
+			return
+		}
+
+		for subscriber := range milestoneStreamSubscribers {
+			select {
+			case subscriber.milestones <- ms:
+			default:
+				// never block the solidifier because of a slow client
+				subscriber.overflowOnce.Do(func() { close(subscriber.overflow) })
+			}
+		}
+	})
+
+	daemon.BackgroundWorker("WebAPI[MilestoneStream]", func(shutdownSignal <-chan struct{}) {
+		tangleplugin.Events.SolidMilestoneIndexChanged.Attach(onSolidMilestoneIndexChanged)
+		<-shutdownSignal
+		tangleplugin.Events.SolidMilestoneIndexChanged.Detach(onSolidMilestoneIndexChanged)
+	}, shutdown.PriorityAPI)
+}
+
+// newStreamMilestone returns the stream message of the milestone with the given index or nil if the milestone is unknown.
+func newStreamMilestone(msIndex milestone.Index) *StreamMilestone {
+	cachedMs := tangle.GetMilestoneOrNil(msIndex) // bundle +1
+	if cachedMs == nil {
+		return nil
+	}
+	defer cachedMs.Release(true) // bundle -1
+
+	cachedTailTx := cachedMs.GetBundle().GetTail() // tx +1
+	if cachedTailTx == nil {
+		return nil
+	}
+	defer cachedTailTx.Release(true) // tx -1
+
+	return &StreamMilestone{
+		Index:         msIndex,
+		MilestoneHash: cachedMs.GetBundle().GetMilestoneHash().Trytes(),
+		Timestamp:     cachedTailTx.GetTransaction().GetTimestamp(),
+	}
+}
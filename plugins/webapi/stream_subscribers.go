@@ -0,0 +1,68 @@
+package webapi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/metrics"
+)
+
+const (
+	// ErrCodeTooManySubscribers is the error code returned if a stream route has no free subscriber slots left.
+	ErrCodeTooManySubscribers = "too_many_subscribers"
+)
+
+var (
+	// ErrTooManySubscribers is returned if the subscriber limit of a stream route or of all stream routes is reached.
+	ErrTooManySubscribers = errors.New("too many stream subscribers")
+
+	streamSubscribersLock sync.Mutex
+	streamSubscribers     = make(map[string]int)
+)
+
+// acquireStreamSubscriber reserves a subscriber slot of the given stream route.
+// the slot has to be released with releaseStreamSubscriber after the connection was closed.
+func acquireStreamSubscriber(route string) error {
+	maxSubscribers := config.NodeConfig.GetInt(config.CfgWebAPIStreamsMaxSubscribers)
+	maxSubscribersPerRoute := config.NodeConfig.GetInt(config.CfgWebAPIStreamsMaxSubscribersPerRoute)
+
+	streamSubscribersLock.Lock()
+	defer streamSubscribersLock.Unlock()
+
+	if maxSubscribers > 0 && int(metrics.SharedServerMetrics.StreamSubscribers.Load()) >= maxSubscribers {
+		return fmt.Errorf("%w: all stream routes are limited to %d subscribers", ErrTooManySubscribers, maxSubscribers)
+	}
+
+	if maxSubscribersPerRoute > 0 && streamSubscribers[route] >= maxSubscribersPerRoute {
+		return fmt.Errorf("%w: route [%s] is limited to %d subscribers", ErrTooManySubscribers, route, maxSubscribersPerRoute)
+	}
+
+	streamSubscribers[route]++
+	metrics.SharedServerMetrics.StreamSubscribers.Inc()
+
+	return nil
+}
+
+// releaseStreamSubscriber frees a subscriber slot of the given stream route.
+func releaseStreamSubscriber(route string) {
+	streamSubscribersLock.Lock()
+	defer streamSubscribersLock.Unlock()
+
+	streamSubscribers[route]--
+	metrics.SharedServerMetrics.StreamSubscribers.Dec()
+}
+
+// StreamSubscriberCounts returns the amount of connected subscribers per stream route.
+func StreamSubscriberCounts() map[string]int {
+	streamSubscribersLock.Lock()
+	defer streamSubscribersLock.Unlock()
+
+	counts := make(map[string]int, len(streamSubscribers))
+	for route, subscribers := range streamSubscribers {
+		counts[route] = subscribers
+	}
+
+	return counts
+}
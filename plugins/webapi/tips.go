@@ -7,10 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/trinary"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/dag"
+	"github.com/gohornet/hornet/pkg/metrics"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/milestone"
 	"github.com/gohornet/hornet/pkg/model/tangle"
@@ -22,8 +24,16 @@ func init() {
 	addEndpoint("getTipInfo", getTipInfo, implementedAPIcalls)
 	addEndpoint("getTransactionsToApprove", getTransactionsToApprove, implementedAPIcalls)
 	addEndpoint("getSpammerTips", getSpammerTips, implementedAPIcalls)
+	addEndpoint("getRecentTipSelections", getRecentTipSelections, implementedAPIcalls)
+	addEndpoint("getTipCount", getTipCount, implementedAPIcalls)
+	addEndpoint("getNonLazyTips", getNonLazyTips, implementedAPIcalls)
 }
 
+const (
+	// maxNonLazyTipsCount is the maximum amount of tips which can be requested via getNonLazyTips.
+	maxNonLazyTipsCount = 8
+)
+
 func getTipInfo(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	e := ErrorReturn{}
 
@@ -152,7 +162,9 @@ func getTransactionsToApprove(i interface{}, c *gin.Context, _ <-chan struct{})
 		return
 	}
 
-	tips, err := urts.TipSelector.SelectNonLazyTips()
+	unsafe := !tangle.IsNodeSyncedWithThreshold()
+
+	tips, fallback, err := selectNonLazyTipsWithFallback()
 	if err != nil {
 		if err == tangle.ErrNodeNotSynced || err == tipselect.ErrNoTipsAvailable {
 			e.Error = err.Error()
@@ -164,17 +176,112 @@ func getTransactionsToApprove(i interface{}, c *gin.Context, _ <-chan struct{})
 		return
 	}
 
+	branch := tips[1].Trytes()
 	if len(query.Reference) > 0 {
 		if !guards.IsTransactionHash(query.Reference) {
 			e.Error = "invalid reference hash supplied"
 			c.JSON(http.StatusBadRequest, e)
 			return
 		}
-		c.JSON(http.StatusOK, GetTransactionsToApproveReturn{TrunkTransaction: tips[0].Trytes(), BranchTransaction: query.Reference})
-		return
+		if query.Extended && !tangle.ContainsTransaction(hornet.HashFromHashTrytes(query.Reference)) {
+			// the metadata of unknown transactions can't be computed
+			e.Error = fmt.Sprintf("reference %s not found", query.Reference)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+		branch = query.Reference
 	}
 
-	c.JSON(http.StatusOK, GetTransactionsToApproveReturn{TrunkTransaction: tips[0].Trytes(), BranchTransaction: tips[1].Trytes()})
+	result := GetTransactionsToApproveReturn{TrunkTransaction: tips[0].Trytes(), BranchTransaction: branch, Fallback: fallback, Unsafe: unsafe}
+	if query.WithProtocol {
+		result.Protocol = currentProtocolParameters()
+	}
+	if query.Extended {
+		lsmi := tangle.GetSolidMilestoneIndex()
+		for _, tip := range []trinary.Hash{result.TrunkTransaction, result.BranchTransaction} {
+			tipMeta := tipMetadata(hornet.HashFromHashTrytes(tip), lsmi)
+			if tipMeta == nil {
+				e.Error = fmt.Sprintf("tip %s not found", tip)
+				c.JSON(http.StatusInternalServerError, e)
+				return
+			}
+			result.Tips = append(result.Tips, tipMeta)
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// tipMetadata returns the root snapshot index deltas and the tip selection score of the given tail transaction.
+// the deltas are computed the same way as in getTipInfo. returns nil if the transaction is unknown.
+func tipMetadata(tailTxHash hornet.Hash, lsmi milestone.Index) *TipMetadata {
+	cachedTxMeta := tangle.GetCachedTxMetadataOrNil(tailTxHash) // meta +1
+	if cachedTxMeta == nil {
+		return nil
+	}
+
+	ytrsi, otrsi := dag.GetTransactionRootSnapshotIndexes(cachedTxMeta, lsmi) // meta -1
+
+	score := "lazy"
+	switch urts.TipSelector.CalculateScoreForIndex(tailTxHash, lsmi) {
+	case tipselect.ScoreNonLazy:
+		score = "nonLazy"
+	case tipselect.ScoreSemiLazy:
+		score = "semiLazy"
+	}
+
+	return &TipMetadata{
+		Hash:       tailTxHash.Trytes(),
+		YTRSIDelta: lsmi - ytrsi,
+		OTRSIDelta: lsmi - otrsi,
+		Score:      score,
+	}
+}
+
+// selectNonLazyTipsWithFallback selects two non-lazy tips.
+// if no tips are available and the fallback is enabled, the tail transaction of the latest solid milestone is returned instead.
+func selectNonLazyTipsWithFallback() (tips hornet.Hashes, fallback bool, err error) {
+
+	tips, err = urts.TipSelector.SelectNonLazyTips()
+	if err != tipselect.ErrNoTipsAvailable || !config.NodeConfig.GetBool(config.CfgTipSelFallbackToLatestMilestone) {
+		return tips, false, err
+	}
+
+	msTailHash, err := latestSolidMilestoneTailHash()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return hornet.Hashes{msTailHash, msTailHash}, true, nil
+}
+
+// selectNonLazyTipsCountWithFallback selects up to count distinct non-lazy tips.
+// if no tips are available and the fallback is enabled, the tail transaction of the latest solid milestone is returned instead.
+func selectNonLazyTipsCountWithFallback(count int) (tips hornet.Hashes, exhausted bool, fallback bool, err error) {
+
+	tips, exhausted, err = urts.TipSelector.SelectNonLazyTipsCount(count)
+	if err != tipselect.ErrNoTipsAvailable || !config.NodeConfig.GetBool(config.CfgTipSelFallbackToLatestMilestone) {
+		return tips, exhausted, false, err
+	}
+
+	msTailHash, err := latestSolidMilestoneTailHash()
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	return hornet.Hashes{msTailHash}, count > 1, true, nil
+}
+
+// latestSolidMilestoneTailHash returns the tail transaction of the latest solid milestone, which is used as tip
+// if the tip pool is empty. returns tipselect.ErrNoTipsAvailable if the milestone is unknown.
+func latestSolidMilestoneTailHash() (hornet.Hash, error) {
+	cachedMs := tangle.GetMilestoneOrNil(tangle.GetSolidMilestoneIndex()) // bundle +1
+	if cachedMs == nil {
+		return nil, tipselect.ErrNoTipsAvailable
+	}
+	defer cachedMs.Release(true) // bundle -1
+
+	return cachedMs.GetBundle().GetTailHash(), nil
 }
 
 func getSpammerTips(i interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -201,3 +308,103 @@ func getSpammerTips(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	c.JSON(http.StatusOK, GetTransactionsToApproveReturn{TrunkTransaction: tips[0].Trytes(), BranchTransaction: tips[1].Trytes()})
 }
+
+// getRecentTipSelections returns the tips recently returned by the tip-selector, newest first,
+// to correlate the attachments of clients with the behavior of the tip-selector.
+func getRecentTipSelections(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	// do not reply if URTS is disabled
+	if node.IsSkipped(urts.PLUGIN) {
+		e.Error = "tipselection plugin disabled in this node"
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	result := GetRecentTipSelectionsReturn{Selections: []*RecentTipSelection{}}
+	for _, selection := range urts.TipSelector.RecentTipSelections() {
+		pool := "nonLazy"
+		if selection.Score == tipselect.ScoreSemiLazy {
+			pool = "semiLazy"
+		}
+
+		result.Selections = append(result.Selections, &RecentTipSelection{
+			Tips:      selection.Tips.Trytes(),
+			Pool:      pool,
+			Timestamp: selection.Timestamp.Unix(),
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getTipCount returns the current amount of tips in the tip pools of the tipselection.
+// the counters are updated by the tipselection whenever the pools change, so no lock of the pools is needed.
+func getTipCount(_ interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	// do not reply if URTS is disabled
+	if node.IsSkipped(urts.PLUGIN) {
+		e.Error = "tipselection plugin disabled in this node"
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	nonLazy := metrics.SharedServerMetrics.TipsNonLazy.Load()
+	semiLazy := metrics.SharedServerMetrics.TipsSemiLazy.Load()
+
+	c.JSON(http.StatusOK, GetTipCountReturn{
+		NonLazy:  nonLazy,
+		SemiLazy: semiLazy,
+		Total:    nonLazy + semiLazy,
+	})
+}
+
+// getNonLazyTips returns up to "count" distinct non-lazy tips (default 2, max 8).
+// if the pool contains less non-lazy tips, all of them are returned and "exhausted" is set.
+// if the pool is empty and "tipsel.fallbackToLatestMilestone" is enabled, the tail transaction of the latest solid milestone is returned.
+// if the node is not synced, no tips are returned unless "tipsel.allowUnsynced" is enabled.
+func getNonLazyTips(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+
+	// do not reply if URTS is disabled
+	if node.IsSkipped(urts.PLUGIN) {
+		e.Error = "tipselection plugin disabled in this node"
+		c.JSON(http.StatusServiceUnavailable, e)
+		return
+	}
+
+	query := &GetNonLazyTips{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if query.Count == 0 {
+		query.Count = 2
+	}
+
+	if query.Count < 0 || query.Count > maxNonLazyTipsCount {
+		e.Error = fmt.Sprintf("invalid count, must be between 1 and %d", maxNonLazyTipsCount)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	unsafe := !tangle.IsNodeSyncedWithThreshold()
+
+	tips, exhausted, fallback, err := selectNonLazyTipsCountWithFallback(query.Count)
+	if err != nil {
+		if err == tangle.ErrNodeNotSynced || err == tipselect.ErrNoTipsAvailable {
+			e.Error = err.Error()
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	c.JSON(http.StatusOK, GetNonLazyTipsReturn{Tips: tips.Trytes(), Exhausted: exhausted, Fallback: fallback, Unsafe: unsafe})
+}
@@ -0,0 +1,53 @@
+package webapi
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/gohornet/hornet/pkg/compressed"
+)
+
+// transactionHashFromBytesRoute computes the hash of a transaction given in the compressed binary format,
+// which is used for the gossip and the storage, without storing or broadcasting the transaction.
+// this allows clients to verify their own serialization.
+func transactionHashFromBytesRoute() {
+	mountRoute(http.MethodPost, "/transactions/hash-from-bytes", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["transactions"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [transactions] is protected"})
+				return
+			}
+		}
+
+		if c.ContentType() != MIMEOctetStream {
+			c.JSON(http.StatusUnsupportedMediaType, ErrorReturn{Error: fmt.Sprintf("content type must be %s", MIMEOctetStream)})
+			return
+		}
+
+		// read one byte more than allowed to detect oversized payloads
+		txBytes, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, compressed.TransactionSize+1))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("failed to read the transaction bytes: %v", err)})
+			return
+		}
+
+		if len(txBytes) > compressed.TransactionSize {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("transaction bytes exceed the maximum size of %d bytes", compressed.TransactionSize)})
+			return
+		}
+
+		tx, err := compressed.TransactionFromCompressedBytes(txBytes)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid transaction bytes: %v", err)})
+			return
+		}
+
+		c.JSON(http.StatusOK, TransactionHashFromBytesReturn{Hash: tx.Hash})
+	})
+}
@@ -0,0 +1,193 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+// transactionFullRoute returns the trytes of the transaction with the given hash together with its metadata.
+// the transaction is loaded only once, so both parts always describe the same state of the transaction.
+func transactionFullRoute() {
+	mountRoute(http.MethodGet, "/transactions/:hash/full", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["transactions"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [transactions] is protected"})
+				return
+			}
+		}
+
+		txHash := c.Param("hash")
+		if !guards.IsTransactionHash(txHash) {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid transaction hash: %s", txHash)})
+			return
+		}
+
+		result, err := transactionFull(txHash)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorReturn{Error: fmt.Sprintf("%v: %v", ErrInternalError, err)})
+			return
+		}
+
+		if result == nil {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("transaction not found: %s", txHash), Code: ErrCodeNotFound})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// transactionMetadataRoute returns the metadata of the transaction with the given hash.
+func transactionMetadataRoute() {
+	mountRoute(http.MethodGet, "/transactions/:hash/metadata", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["transactions"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [transactions] is protected"})
+				return
+			}
+		}
+
+		txHash := c.Param("hash")
+		if !guards.IsTransactionHash(txHash) {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid transaction hash: %s", txHash)})
+			return
+		}
+
+		cachedTxMeta := tangle.GetCachedTxMetadataOrNil(hornet.HashFromHashTrytes(txHash)) // meta +1
+		if cachedTxMeta == nil {
+			c.JSON(http.StatusNotFound, ErrorReturn{Error: fmt.Sprintf("transaction not found: %s", txHash), Code: ErrCodeNotFound})
+			return
+		}
+		defer cachedTxMeta.Release(true) // meta -1
+
+		c.JSON(http.StatusOK, newTransactionMetadataReturn(cachedTxMeta.GetMetadata()))
+	})
+}
+
+// transactionsByHashesRoute returns the trytes and the metadata of all transactions with the given hashes.
+// the body is a JSON array of transaction hashes, the results are returned in the same order.
+// unknown or invalid hashes don't fail the whole request, they are marked with an error in their entry instead.
+func transactionsByHashesRoute() {
+	mountRoute(http.MethodPost, "/transactions/by-hashes", func(c *gin.Context) {
+
+		if !networkWhitelisted(c) {
+			// network is not whitelisted, check if the route is permitted, otherwise deny it.
+			if _, permitted := permittedRESTroutes["transactions"]; !permitted {
+				c.JSON(http.StatusForbidden, ErrorReturn{Error: "route [transactions] is protected"})
+				return
+			}
+		}
+
+		var txHashes []trinary.Hash
+		if err := c.ShouldBindJSON(&txHashes); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("invalid request body, expected a JSON array of transaction hashes: %v", err)})
+			return
+		}
+
+		maxTransactionsByHashes := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxTransactionsByHashes)
+		if len(txHashes) > maxTransactionsByHashes {
+			c.JSON(http.StatusBadRequest, ErrorReturn{Error: fmt.Sprintf("too many transaction hashes: %d, max. allowed: %d", len(txHashes), maxTransactionsByHashes)})
+			return
+		}
+
+		result := &TransactionsByHashesReturn{Transactions: make([]*TransactionByHash, 0, len(txHashes))}
+		for _, txHash := range txHashes {
+			entry := &TransactionByHash{Hash: txHash}
+			result.Transactions = append(result.Transactions, entry)
+
+			if !guards.IsTransactionHash(txHash) {
+				entry.Error = "invalid transaction hash"
+				entry.Code = ErrCodeInvalidTrytes
+				continue
+			}
+
+			tx, err := transactionFull(txHash)
+			if err != nil {
+				entry.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+				continue
+			}
+
+			if tx == nil {
+				entry.Error = "transaction not found"
+				entry.Code = ErrCodeNotFound
+				continue
+			}
+			entry.Transaction = tx
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// transactionFull loads the transaction with the given hash and returns its trytes together with its metadata.
+// the transaction is loaded only once, so both parts always describe the same state of the transaction.
+// returns nil if the transaction is unknown.
+func transactionFull(txHash trinary.Hash) (*TransactionFullReturn, error) {
+	cachedTx := tangle.GetCachedTransactionOrNil(hornet.HashFromHashTrytes(txHash)) // tx +1
+	if cachedTx == nil {
+		return nil, nil
+	}
+
+	var result *TransactionFullReturn
+	var err error
+	cachedTx.ConsumeTransactionAndMetadata(func(tx *hornet.Transaction, metadata *hornet.TransactionMetadata) { // tx -1
+		var trytes string
+		if trytes, err = transaction.TransactionToTrytes(tx.Tx); err != nil {
+			return
+		}
+		result = &TransactionFullReturn{
+			Trytes:   trytes,
+			Metadata: newTransactionMetadataReturn(metadata),
+		}
+	})
+
+	return result, err
+}
+
+// newTransactionMetadataReturn converts the metadata of a transaction to its API representation.
+func newTransactionMetadataReturn(metadata *hornet.TransactionMetadata) *TransactionMetadataReturn {
+	confirmed, confirmationIndex := metadata.GetConfirmed()
+	yrtsi, ortsi, _ := metadata.GetRootSnapshotIndexes()
+
+	result := &TransactionMetadataReturn{
+		Hash:                      metadata.GetTxHash().Trytes(),
+		BundleHash:                metadata.GetBundleHash().Trytes(),
+		TrunkTransaction:          metadata.GetTrunkHash().Trytes(),
+		BranchTransaction:         metadata.GetBranchHash().Trytes(),
+		IsTail:                    metadata.IsTail(),
+		IsHead:                    metadata.IsHead(),
+		Solid:                     metadata.IsSolid(),
+		SolidificationTimestamp:   metadata.GetSolidificationTimestamp(),
+		Confirmed:                 confirmed,
+		ConfirmationIndex:         confirmationIndex,
+		Conflicting:               metadata.IsConflicting(),
+		YoungestRootSnapshotIndex: yrtsi,
+		OldestRootSnapshotIndex:   ortsi,
+	}
+
+	if confirmed {
+		if _, msTimestamp, err := getMilestoneHashAndTimestamp(confirmationIndex); err == nil {
+			result.MilestoneTimestamp = &msTimestamp
+		}
+	}
+
+	if whiteFlagIndex, known := metadata.GetWhiteFlagIndex(); known {
+		result.WhiteFlagIndex = &whiteFlagIndex
+	}
+
+	return result
+}
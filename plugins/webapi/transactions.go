@@ -3,19 +3,50 @@ package webapi
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/iotaledger/hive.go/node"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/iotaledger/iota.go/address"
 	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/transaction"
 	"github.com/iotaledger/iota.go/trinary"
 
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 	"github.com/gohornet/hornet/plugins/gossip"
+	"github.com/gohornet/hornet/plugins/peering"
+	"github.com/gohornet/hornet/plugins/reattacher"
+	"github.com/gohornet/hornet/plugins/urts"
+)
+
+const (
+	// FindTransactionsSortHash sorts the results of findTransactions by transaction hash.
+	FindTransactionsSortHash = "hash"
+	// FindTransactionsSortNewest sorts the results of findTransactions by timestamp, newest first.
+	// transactions with the same timestamp are sorted by transaction hash.
+	FindTransactionsSortNewest = "newest"
+)
+
+const (
+	// ErrCodeNotEnoughPeers is the error code returned if the node is connected to too few peers to broadcast transactions.
+	ErrCodeNotEnoughPeers = "not_enough_peers"
+	// ErrCodeDataTooLarge is the error code returned if a zero-value bundle contains more message data than allowed.
+	ErrCodeDataTooLarge = "data_too_large"
+	// ErrCodeHashMismatch is the error code returned if the hash of a transaction differs from the hash expected by the client.
+	ErrCodeHashMismatch = "hash_mismatch"
+	// ErrCodePoWNotAvailable is the error code returned if transactions without a valid nonce are submitted
+	// to a node which does not perform the PoW for the caller.
+	ErrCodePoWNotAvailable = "pow_not_available"
+	// ErrCodePoWTimeout is the error code returned if the PoW of attachToTangle didn't finish within the timeout.
+	// the request can be retried later, when the node is less busy.
+	ErrCodePoWTimeout = "pow_timeout"
 )
 
 func init() {
@@ -40,22 +71,229 @@ func broadcastTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
-	for _, trytes := range query.Trytes {
-		if err := trinary.ValidTrytes(trytes); err != nil {
-			e.Error = err.Error()
+	// transactions which are not broadcasted are unknown to the rest of the network.
+	// other nodes will only request them if they receive transactions which approve them,
+	// until then the transactions can't be confirmed and their approvers can't become solid on other nodes.
+	skipBroadcast := query.Broadcast != nil && !*query.Broadcast
+	if skipBroadcast {
+		if !config.NodeConfig.GetBool(config.CfgWebAPIAllowSkipBroadcast) {
+			e.Error = "storing transactions without broadcasting them is not allowed on this node"
+			c.JSON(http.StatusForbidden, e)
+			return
+		}
+
+		if query.AutoReattach {
+			e.Error = "autoReattach can't be used if the transactions are not broadcasted"
 			c.JSON(http.StatusBadRequest, e)
 			return
 		}
 	}
 
-	for _, trytes := range query.Trytes {
-		if err := gossip.Processor().ValidateTransactionTrytesAndEmit(trytes); err != nil {
+	if len(query.ExpectedHashes) > 0 && len(query.ExpectedHashes) != len(query.Trytes) {
+		e.Error = "the amount of expected hashes must match the amount of trytes"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	// retries with the same idempotency key are not broadcasted again
+	idemKey := idempotencyKey(c, "broadcastTransactions")
+	requestDigest := idempotencyDigest(query.Trytes...)
+	if replayIdempotentResponse(c, idemKey, requestDigest) {
+		return
+	}
+	defer releaseIdempotencyKey(idemKey)
+
+	// all problems of the transactions are collected, so clients can show them at once
+	txs, problems := validateBroadcastTransactions(query, remotePoWAvailable(c))
+	if len(problems) > 0 {
+		writeValidationProblems(c, problems, query.FailFast)
+		return
+	}
+
+	var autoReattachTxs transaction.Transactions
+	if query.AutoReattach {
+		if node.IsSkipped(reattacher.PLUGIN) {
+			e.Error = "reattacher plugin disabled in this node"
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+
+		// only complete bundles can be reattached
+		for i := range txs {
+			if txs[i].Bundle != txs[0].Bundle || uint64(len(txs)) != txs[i].LastIndex+1 {
+				e.Error = "autoReattach requires the transactions of exactly one complete bundle"
+				c.JSON(http.StatusBadRequest, e)
+				return
+			}
+		}
+		autoReattachTxs = txs
+	}
+
+	if !skipBroadcast {
+		if err := checkConnectedPeers(); err != nil {
 			e.Error = err.Error()
-			c.JSON(http.StatusBadRequest, e)
+			e.Code = ErrCodeNotEnoughPeers
+			c.JSON(http.StatusServiceUnavailable, e)
 			return
 		}
 	}
-	c.JSON(http.StatusOK, BradcastTransactionsReturn{})
+
+	if query.OnlyIfTips {
+		// do not reply if URTS is disabled
+		if node.IsSkipped(urts.PLUGIN) {
+			e.Error = "tipselection plugin disabled in this node"
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		}
+
+		// collect the approvees which are not part of the given transactions
+		txHashes := make(map[trinary.Hash]struct{})
+		for _, tx := range txs {
+			txHashes[tx.Hash] = struct{}{}
+		}
+
+		approvees := make(map[trinary.Hash]struct{})
+		for _, tx := range txs {
+			for _, approvee := range []trinary.Hash{tx.TrunkTransaction, tx.BranchTransaction} {
+				if _, isOwnTx := txHashes[approvee]; !isOwnTx {
+					approvees[approvee] = struct{}{}
+				}
+			}
+		}
+
+		approveeHashes := hornet.Hashes{}
+		for approvee := range approvees {
+			approveeHashes = append(approveeHashes, hornet.HashFromHashTrytes(approvee))
+		}
+
+		// check the tip pools right before the submission, so the client can re-select the tips
+		if !urts.TipSelector.ContainsTips(approveeHashes) {
+			e.Error = "the referenced transactions are no longer tips"
+			c.JSON(http.StatusConflict, e)
+			return
+		}
+	}
+
+	reservation, problem := reserveBroadcastTagQuotas(txs)
+	if problem != nil {
+		writeValidationProblems(c, []*validationProblem{problem}, query.FailFast)
+		return
+	}
+
+	result := BradcastTransactionsReturn{}
+
+	if skipBroadcast {
+		for i, trytes := range query.Trytes {
+			if err := gossip.Processor().ValidateTransactionTrytesAndStore(trytes); err != nil {
+				reservation.release()
+				e.Error = err.Error()
+				c.JSON(http.StatusBadRequest, e)
+				return
+			}
+			result.Hashes = append(result.Hashes, txs[i].Hash)
+		}
+	} else {
+		for _, trytes := range query.Trytes {
+			if err := gossip.Processor().ValidateTransactionTrytesAndEmit(trytes); err != nil {
+				reservation.release()
+				e.Error = err.Error()
+				c.JSON(http.StatusBadRequest, e)
+				return
+			}
+		}
+	}
+
+	if autoReattachTxs != nil {
+		if _, err := reattacher.Track(autoReattachTxs); err != nil {
+			result.AutoReattachError = err.Error()
+		}
+	}
+	storeIdempotentResponse(idemKey, requestDigest, result)
+
+	c.JSON(http.StatusOK, result)
+}
+
+// writeValidationProblems writes either the first validation problem or all of them to the client.
+func writeValidationProblems(c *gin.Context, problems []*validationProblem, failFast bool) {
+	if failFast {
+		c.JSON(problems[0].status, problems[0].response)
+		return
+	}
+
+	result := ValidationFailedReturn{
+		Error:    fmt.Sprintf("the transactions have %d validation problems", len(problems)),
+		Code:     ErrCodeValidationFailed,
+		Problems: make([]*ValidationProblem, len(problems)),
+	}
+	for i, problem := range problems {
+		result.Problems[i] = problem.problem
+	}
+	c.JSON(http.StatusBadRequest, result)
+}
+
+// checkConnectedPeers checks whether the node is connected to enough peers to propagate broadcasted transactions,
+// so that clients don't assume their transactions reached the network if they didn't.
+// networks with a single node have to keep the check disabled.
+func checkConnectedPeers() error {
+	minConnectedPeers := config.NodeConfig.GetInt(config.CfgWebAPIMinConnectedPeers)
+	if minConnectedPeers <= 0 {
+		return nil
+	}
+
+	if connectedPeers := peering.Manager().ConnectedPeerCount(); connectedPeers < minConnectedPeers {
+		return fmt.Errorf("node is connected to %d peers, at least %d are needed to broadcast transactions", connectedPeers, minConnectedPeers)
+	}
+
+	return nil
+}
+
+// checkDataSize checks that the zero-value bundles of the given transactions don't carry more than the allowed
+// amount of message trytes, independent of the fixed size of the transactions.
+// bundles which transfer value are not checked, since their signature fragments are not arbitrary data.
+func checkDataSize(txs transaction.Transactions, maxDataTrytes int) error {
+	dataTrytesPerBundle := make(map[trinary.Hash]int)
+	valueBundles := make(map[trinary.Hash]struct{})
+
+	for _, tx := range txs {
+		if tx.Value != 0 {
+			valueBundles[tx.Bundle] = struct{}{}
+			continue
+		}
+		// trailing 9s are padding
+		dataTrytesPerBundle[tx.Bundle] += len(strings.TrimRight(tx.SignatureMessageFragment, "9"))
+	}
+
+	for bundleHash, dataTrytes := range dataTrytesPerBundle {
+		if _, isValueBundle := valueBundles[bundleHash]; isValueBundle {
+			continue
+		}
+
+		if dataTrytes > maxDataTrytes {
+			return fmt.Errorf("bundle %s contains %d message trytes, max. allowed: %d", bundleHash, dataTrytes, maxDataTrytes)
+		}
+	}
+
+	return nil
+}
+
+// checkAttachmentTimestamps checks that the attachment timestamps of the given transactions are within the allowed skew
+// relative to the clock of the node, to reject transactions with precomputed PoW which are replayed later.
+// transactions without an attachment timestamp are not checked.
+func checkAttachmentTimestamps(txs transaction.Transactions, maxSkew time.Duration) error {
+	now := time.Now()
+
+	for _, tx := range txs {
+		if tx.AttachmentTimestamp == 0 {
+			continue
+		}
+
+		attachmentTime := time.Unix(0, tx.AttachmentTimestamp*int64(time.Millisecond))
+		if attachmentTime.Before(now.Add(-maxSkew)) || attachmentTime.After(now.Add(maxSkew)) {
+			return fmt.Errorf("attachment timestamp of transaction %s is not within %v of the node's time", tx.Hash, maxSkew)
+		}
+	}
+
+	return nil
 }
 
 func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
@@ -68,6 +306,16 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
+	switch query.Sort {
+	case "":
+		query.Sort = FindTransactionsSortHash
+	case FindTransactionsSortHash, FindTransactionsSortNewest:
+	default:
+		e.Error = fmt.Sprintf("invalid sort order: %s, allowed: %s, %s", query.Sort, FindTransactionsSortHash, FindTransactionsSortNewest)
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
 	maxResults := config.NodeConfig.GetInt(config.CfgWebAPILimitsMaxFindTransactions)
 	if (query.MaxResults != 0) && (query.MaxResults < maxResults) {
 		maxResults = query.MaxResults
@@ -232,15 +480,42 @@ func findTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		}
 	}
 
-	// convert to slice
-	var j int
-	txHashes := make([]string, len(results))
+	c.JSON(http.StatusOK, FindTransactionsReturn{Hashes: sortFindTransactionsResults(results, query.Sort)})
+}
+
+// sortFindTransactionsResults converts the results of findTransactions to a slice in a deterministic order,
+// so that the same query returns the same order on every call.
+func sortFindTransactionsResults(results map[string]struct{}, sortOrder string) []trinary.Hash {
+
+	txHashes := make([]trinary.Hash, 0, len(results))
+	timestamps := make(map[trinary.Hash]int64, len(results))
 	for r := range results {
-		txHashes[j] = hornet.Hash(r).Trytes()
-		j++
+		txHash := hornet.Hash(r).Trytes()
+		txHashes = append(txHashes, txHash)
+
+		if sortOrder != FindTransactionsSortNewest {
+			continue
+		}
+
+		cachedTx := tangle.GetCachedTransactionOrNil(hornet.Hash(r)) // tx +1
+		if cachedTx == nil {
+			// the transaction was pruned in the meantime, sort it to the end
+			continue
+		}
+		timestamps[txHash] = cachedTx.GetTransaction().GetTimestamp()
+		cachedTx.Release(true) // tx -1
 	}
 
-	c.JSON(http.StatusOK, FindTransactionsReturn{Hashes: txHashes})
+	sort.Slice(txHashes, func(i, j int) bool {
+		if sortOrder == FindTransactionsSortNewest {
+			if tsI, tsJ := timestamps[txHashes[i]], timestamps[txHashes[j]]; tsI != tsJ {
+				return tsI > tsJ
+			}
+		}
+		return txHashes[i] < txHashes[j]
+	})
+
+	return txHashes
 }
 
 // redirect to broadcastTransactions
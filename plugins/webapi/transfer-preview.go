@@ -0,0 +1,157 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/consts"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+const (
+	// TransferPreviewErrInsufficientFunds is reported if the inputs do not cover the outputs.
+	TransferPreviewErrInsufficientFunds = "insufficient_funds"
+	// TransferPreviewErrInputWithoutBalance is reported if an input address has no confirmed balance.
+	TransferPreviewErrInputWithoutBalance = "input_without_balance"
+	// TransferPreviewErrInputSpent is reported if an input address was already spent from.
+	// spending from it again would reveal a further part of its private key.
+	TransferPreviewErrInputSpent = "input_spent"
+	// TransferPreviewErrOutputSpent is reported if an output address was already spent from.
+	TransferPreviewErrOutputSpent = "output_spent"
+)
+
+func init() {
+	addEndpoint("previewTransfer", previewTransfer, implementedAPIcalls)
+}
+
+// previewTransfer computes the balance math of a proposed transfer without constructing or submitting anything.
+// an input always spends the whole confirmed balance of its address, the remainder has to be sent to a change address.
+// problems of the transfer, like insufficient funds, are reported in the errors of the preview.
+func previewTransfer(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &PreviewTransfer{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if len(query.Inputs) == 0 {
+		e.Error = "No inputs provided"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	if len(query.Outputs) == 0 {
+		e.Error = "No outputs provided"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	inputAddresses := make(map[string]struct{})
+	for _, addr := range query.Inputs {
+		if err := address.ValidAddress(addr); err != nil {
+			e.Error = fmt.Sprintf("%v: %v", err, addr)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		addrHash := hornet.HashFromAddressTrytes(addr)
+		if _, exists := inputAddresses[string(addrHash)]; exists {
+			e.Error = fmt.Sprintf("Duplicate input: %v", addr)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+		inputAddresses[string(addrHash)] = struct{}{}
+	}
+
+	var totalOutput uint64
+	for _, output := range query.Outputs {
+		if err := address.ValidAddress(output.Address); err != nil {
+			e.Error = fmt.Sprintf("%v: %v", err, output.Address)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		if _, isInput := inputAddresses[string(hornet.HashFromAddressTrytes(output.Address))]; isInput {
+			e.Error = fmt.Sprintf("Output address is also used as input: %v", output.Address)
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+
+		totalOutput += output.Value
+		if output.Value > consts.TotalSupply || totalOutput > consts.TotalSupply {
+			e.Error = "Total output exceeds the total supply"
+			c.JSON(http.StatusBadRequest, e)
+			return
+		}
+	}
+
+	if !tangle.WaitForNodeSynced(waitForNodeSyncedTimeout) {
+		e.Error = ErrNodeNotSync.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	spentAddressesEnabled := tangle.GetSnapshotInfo().IsSpentAddressesEnabled()
+
+	tangle.ReadLockLedger()
+	defer tangle.ReadUnlockLedger()
+
+	result := PreviewTransferReturn{
+		Inputs:      make([]*TransferPreviewInput, 0, len(query.Inputs)),
+		TotalOutput: totalOutput,
+		Errors:      []*TransferPreviewError{},
+	}
+
+	for _, addr := range query.Inputs {
+		addrHash := hornet.HashFromAddressTrytes(addr)
+
+		balance, ledgerIndex, err := tangle.GetBalanceForAddressWithoutLocking(addrHash)
+		if err != nil {
+			e.Error = "Ledger state invalid"
+			c.JSON(http.StatusInternalServerError, e)
+			return
+		}
+		result.LedgerIndex = ledgerIndex
+
+		input := &TransferPreviewInput{Address: addr, Balance: balance}
+		if spentAddressesEnabled {
+			input.Spent = tangle.WasAddressSpentFrom(addrHash)
+		}
+		result.Inputs = append(result.Inputs, input)
+		result.TotalInput += balance
+
+		if balance == 0 {
+			result.Errors = append(result.Errors, &TransferPreviewError{Code: TransferPreviewErrInputWithoutBalance, Address: addr, Message: "input address has no confirmed balance"})
+		}
+		if input.Spent {
+			result.Errors = append(result.Errors, &TransferPreviewError{Code: TransferPreviewErrInputSpent, Address: addr, Message: "input address was already spent from"})
+		}
+	}
+
+	if spentAddressesEnabled {
+		for _, output := range query.Outputs {
+			if tangle.WasAddressSpentFrom(hornet.HashFromAddressTrytes(output.Address)) {
+				result.Errors = append(result.Errors, &TransferPreviewError{Code: TransferPreviewErrOutputSpent, Address: output.Address, Message: "output address was already spent from"})
+			}
+		}
+	}
+
+	if result.TotalInput >= result.TotalOutput {
+		result.Change = result.TotalInput - result.TotalOutput
+	} else {
+		result.Shortfall = result.TotalOutput - result.TotalInput
+		result.Errors = append(result.Errors, &TransferPreviewError{Code: TransferPreviewErrInsufficientFunds, Message: fmt.Sprintf("inputs are missing %d to cover the outputs", result.Shortfall)})
+	}
+	result.Valid = len(result.Errors) == 0
+
+	c.JSON(http.StatusOK, result)
+}
@@ -1,6 +1,8 @@
 package webapi
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -11,16 +13,26 @@ import (
 
 	"github.com/iotaledger/iota.go/guards"
 	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
 
 	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/hornet"
 	"github.com/gohornet/hornet/pkg/model/tangle"
 )
 
+const (
+	// MIMEOctetStream is the content type of the binary response of getTrytes.
+	MIMEOctetStream = "application/octet-stream"
+)
+
 func init() {
 	addEndpoint("getTrytes", getTrytes, implementedAPIcalls)
 }
 
+// getTrytes returns the trytes of the transactions with the given hashes.
+// if the client accepts "application/octet-stream", the transactions are returned in the compressed format
+// of the gossip protocol instead, each prefixed by its length as big endian uint16.
+// unknown transactions have a length of zero.
 func getTrytes(i interface{}, c *gin.Context, _ <-chan struct{}) {
 	e := ErrorReturn{}
 	query := &GetTrytes{}
@@ -39,6 +51,11 @@ func getTrytes(i interface{}, c *gin.Context, _ <-chan struct{}) {
 		return
 	}
 
+	if c.NegotiateFormat(gin.MIMEJSON, MIMEOctetStream) == MIMEOctetStream {
+		getTrytesBinary(query.Hashes, c)
+		return
+	}
+
 	trytes := []string{}
 
 	for _, hash := range query.Hashes {
@@ -72,3 +89,22 @@ func getTrytes(i interface{}, c *gin.Context, _ <-chan struct{}) {
 
 	c.JSON(http.StatusOK, GetTrytesReturn{Trytes: trytes})
 }
+
+// getTrytesBinary writes the length-prefixed compressed transaction bytes of the given hashes.
+func getTrytesBinary(hashes []trinary.Hash, c *gin.Context) {
+	var buf bytes.Buffer
+	for _, hash := range hashes {
+		cachedTx := tangle.GetCachedTransactionOrNil(hornet.HashFromHashTrytes(hash)) // tx +1
+		if cachedTx == nil {
+			binary.Write(&buf, binary.BigEndian, uint16(0))
+			continue
+		}
+
+		txBytes := cachedTx.GetTransaction().RawBytes
+		binary.Write(&buf, binary.BigEndian, uint16(len(txBytes)))
+		buf.Write(txBytes)
+		cachedTx.Release(true) // tx -1
+	}
+
+	c.Data(http.StatusOK, MIMEOctetStream, buf.Bytes())
+}
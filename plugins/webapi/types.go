@@ -3,8 +3,12 @@ package webapi
 import (
 	"github.com/iotaledger/iota.go/trinary"
 
+	"github.com/gohornet/hornet/pkg/config"
 	"github.com/gohornet/hornet/pkg/model/milestone"
+	peeringpkg "github.com/gohornet/hornet/pkg/peering"
 	"github.com/gohornet/hornet/pkg/peering/peer"
+	"github.com/gohornet/hornet/plugins/reattacher"
+	tangleplugin "github.com/gohornet/hornet/plugins/tangle"
 )
 
 //////////////////// addNeighbors /////////////////////////////////
@@ -43,25 +47,190 @@ type AttachToTangle struct {
 	BranchTransaction  trinary.Hash     `mapstructure:"branchTransaction"`
 	MinWeightMagnitude int              `mapstructure:"minWeightMagnitude,omitempty"`
 	Trytes             []trinary.Trytes `mapstructure:"trytes"`
+	// PoWProvided skips the PoW and only verifies the nonces, if the client already did the PoW.
+	PoWProvided bool `mapstructure:"powProvided"`
+	// PoWTimeoutSeconds overrides the default PoW timeout, it is capped by "httpAPI.maxPoWTimeoutSeconds".
+	PoWTimeoutSeconds int `mapstructure:"powTimeoutSeconds"`
 }
 
 // AttachToTangleReturn struct
 type AttachToTangleReturn struct {
-	Trytes   []trinary.Trytes `json:"trytes"`
-	Duration int              `json:"duration"`
+	Trytes []trinary.Trytes `json:"trytes"`
+	// TrunkTransaction and BranchTransaction are only set if the node selected the tips itself.
+	TrunkTransaction  trinary.Hash `json:"trunkTransaction,omitempty"`
+	BranchTransaction trinary.Hash `json:"branchTransaction,omitempty"`
+	// Unsafe is set if the node selected the tips while it was not synced.
+	// transactions attached to these tips may be orphaned.
+	Unsafe   bool `json:"unsafe,omitempty"`
+	Duration int  `json:"duration"`
+}
+
+////////////////// getPoWParameters //////////////////////////
+
+// GetPoWParameters struct
+type GetPoWParameters struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetPoWParametersReturn struct
+type GetPoWParametersReturn struct {
+	MinWeightMagnitude int     `json:"minWeightMagnitude"`
+	ExpectedAttempts   float64 `json:"expectedAttempts"`
+	// RemotePoW is set if the node performs the PoW for the caller via attachToTangle.
+	RemotePoW bool   `json:"remotePoW"`
+	PoWType   string `json:"powType,omitempty"`
+	Duration  int    `json:"duration"`
+}
+
+////////////////// getBundleEssence //////////////////////////
+
+// GetBundleEssence struct
+type GetBundleEssence struct {
+	Command string           `mapstructure:"command"`
+	Trytes  []trinary.Trytes `mapstructure:"trytes"`
+}
+
+// GetBundleEssenceReturn struct
+type GetBundleEssenceReturn struct {
+	BundleHash           trinary.Hash     `json:"bundleHash"`
+	NormalizedBundleHash []int8           `json:"normalizedBundleHash"`
+	ObsoleteTag          trinary.Trytes   `json:"obsoleteTag"`
+	Essences             []trinary.Trytes `json:"essences"`
+	Duration             int              `json:"duration"`
+}
+
+////////////////// getBundleInputs //////////////////////////
+
+// GetBundleInputs struct
+type GetBundleInputs struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// BundleInput struct
+type BundleInput struct {
+	TxHash       trinary.Hash `json:"txHash"`
+	CurrentIndex uint64       `json:"currentIndex"`
+	Address      trinary.Hash `json:"address"`
+	Value        int64        `json:"value"`
+}
+
+// GetBundleInputsReturn struct
+type GetBundleInputsReturn struct {
+	BundleHash     trinary.Hash    `json:"bundleHash"`
+	Inputs         []*BundleInput  `json:"inputs"`
+	Confirmed      bool            `json:"confirmed"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+	// Conflicting signals that the funds of the inputs may have been spent by another bundle.
+	Conflicting bool `json:"conflicting"`
+	Duration    int  `json:"duration"`
+}
+
+////////////////// getBundleOutputs //////////////////////////
+
+// GetBundleOutputs struct
+type GetBundleOutputs struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// BundleOutput struct
+type BundleOutput struct {
+	TxHash       trinary.Hash `json:"txHash"`
+	CurrentIndex uint64       `json:"currentIndex"`
+	Address      trinary.Hash `json:"address"`
+	Value        int64        `json:"value"`
+	// Spent signals that the address of the output was spent from.
+	Spent bool `json:"spent"`
+}
+
+// GetBundleOutputsReturn struct
+type GetBundleOutputsReturn struct {
+	BundleHash     trinary.Hash    `json:"bundleHash"`
+	Outputs        []*BundleOutput `json:"outputs"`
+	Confirmed      bool            `json:"confirmed"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+	Conflicting    bool            `json:"conflicting"`
+	// SpentAddressesEnabled signals whether the spent state of the outputs is known.
+	SpentAddressesEnabled bool `json:"spentAddressesEnabled"`
+	Duration              int  `json:"duration"`
 }
 
 ////////////////// broadcastTransactions //////////////////////////
 
 // BroadcastTransactions struct
 type BroadcastTransactions struct {
-	Command string           `mapstructure:"command"`
-	Trytes  []trinary.Trytes `mapstructure:"trytes"`
+	Command      string           `mapstructure:"command"`
+	Trytes       []trinary.Trytes `mapstructure:"trytes"`
+	OnlyIfTips   bool             `mapstructure:"onlyIfTips,omitempty"`
+	AutoReattach bool             `mapstructure:"autoReattach,omitempty"`
+	// Broadcast set to false stores the transactions without broadcasting them to the neighbors.
+	Broadcast *bool `mapstructure:"broadcast,omitempty"`
+	// ExpectedHashes are the hashes of the transactions computed by the client, in the same order as the trytes.
+	ExpectedHashes []trinary.Hash `mapstructure:"expectedHashes,omitempty"`
+	// FailFast set to true only returns the first validation problem, with the status code of the problem.
+	FailFast bool `mapstructure:"failFast,omitempty"`
+}
+
+// ValidationProblem struct
+type ValidationProblem struct {
+	// Index is the index of the affected transaction in the request, it is omitted for problems of the whole request.
+	Index   *int   `json:"index,omitempty"`
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationFailedReturn struct
+type ValidationFailedReturn struct {
+	Error    string               `json:"error"`
+	Code     string               `json:"code"`
+	Problems []*ValidationProblem `json:"problems"`
+}
+
+// HashMismatchReturn struct
+type HashMismatchReturn struct {
+	Error        string       `json:"error"`
+	Code         string       `json:"code"`
+	Index        int          `json:"index"`
+	ExpectedHash trinary.Hash `json:"expectedHash"`
+	ComputedHash trinary.Hash `json:"computedHash"`
 }
 
 // BradcastTransactionsReturn struct
 type BradcastTransactionsReturn struct {
-	Duration int `json:"duration"`
+	// Hashes contains the hashes of the stored transactions if they were not broadcasted.
+	Hashes            []trinary.Hash `json:"hashes,omitempty"`
+	AutoReattachError string         `json:"autoReattachError,omitempty"`
+	Duration          int            `json:"duration"`
+}
+
+////////////////// getAutoReattachments //////////////////////////
+
+// GetAutoReattachments struct
+type GetAutoReattachments struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetAutoReattachmentsReturn struct
+type GetAutoReattachmentsReturn struct {
+	Bundles  []*reattacher.TrackedBundle `json:"bundles"`
+	Duration int                         `json:"duration"`
+}
+
+////////////////// validateTransactions //////////////////////////
+
+// ValidateTransactions struct
+type ValidateTransactions struct {
+	Command string           `mapstructure:"command"`
+	Trytes  []trinary.Trytes `mapstructure:"trytes"`
+}
+
+// ValidateTransactionsReturn struct
+type ValidateTransactionsReturn struct {
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+	Duration int      `json:"duration"`
 }
 
 /////////////////// checkConsistency //////////////////////////////
@@ -77,6 +246,7 @@ type CheckConsistencyReturn struct {
 // ErrorReturn struct
 type ErrorReturn struct {
 	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
 }
 
 // ResultReturn struct
@@ -95,6 +265,9 @@ type FindTransactions struct {
 	Approvees  []trinary.Hash `mapstructure:"approvees"`
 	MaxResults int            `mapstructure:"maxresults"`
 	ValueOnly  bool           `json:"valueOnly"`
+	// Sort defines the order of the returned hashes ("hash" (default) or "newest").
+	// if more transactions match than maxResults allows, the subset of returned transactions is not guaranteed to be stable.
+	Sort string `mapstructure:"sort"`
 }
 
 // FindTransactionsReturn struct
@@ -103,19 +276,91 @@ type FindTransactionsReturn struct {
 	Duration int            `json:"duration"`
 }
 
+/////////////////// addressTransactions ////////////////////////////
+
+// AddressTransactionsReturn struct
+type AddressTransactionsReturn struct {
+	Address trinary.Hash   `json:"address"`
+	Hashes  []trinary.Hash `json:"hashes"`
+	// Count is the amount of hashes in the current page.
+	Count int `json:"count"`
+	// Cursor is empty on the last page, otherwise it has to be passed as "cursor" to get the next page.
+	Cursor trinary.Hash `json:"cursor"`
+}
+
 ///////////////////// getBalances /////////////////////////////////
 
 // GetBalances struct
 type GetBalances struct {
 	Command   string         `mapstructure:"command"`
 	Addresses []trinary.Hash `mapstructure:"addresses"`
+	// IncludeConflicting additionally returns the value of confirmed but conflicting transactions per address (debug only).
+	IncludeConflicting bool `mapstructure:"includeConflicting,omitempty"`
 }
 
 // GetBalancesReturn struct
 type GetBalancesReturn struct {
+	// Balances never contain the value of conflicting transactions, since these are not applied to the ledger.
 	Balances       []trinary.Hash  `json:"balances"`
 	References     []trinary.Hash  `json:"references"`
 	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// DebugConflictingValues contains the summed up value of the confirmed but conflicting transactions per address.
+	DebugConflictingValues []string `json:"debugConflictingValues,omitempty"`
+	Duration               int      `json:"duration"`
+}
+
+/////////////////// getAddressBalances ////////////////////////////
+
+// GetAddressBalances struct
+type GetAddressBalances struct {
+	Command   string         `mapstructure:"command"`
+	Addresses []trinary.Hash `mapstructure:"addresses"`
+}
+
+// GetAddressBalancesReturn struct
+type GetAddressBalancesReturn struct {
+	Balances       map[trinary.Hash]string `json:"balances"`
+	Errors         map[trinary.Hash]string `json:"errors"`
+	MilestoneIndex milestone.Index         `json:"milestoneIndex"`
+	Duration       int                     `json:"duration"`
+}
+
+/////////////////// getPendingDeposits ////////////////////////////
+
+// GetPendingDeposits struct
+type GetPendingDeposits struct {
+	Command string       `mapstructure:"command"`
+	Address trinary.Hash `mapstructure:"address"`
+}
+
+// PendingDeposit struct
+type PendingDeposit struct {
+	TxHash     trinary.Hash `json:"txHash"`
+	BundleHash trinary.Hash `json:"bundleHash"`
+	Value      int64        `json:"value"`
+}
+
+// GetPendingDepositsReturn struct
+type GetPendingDepositsReturn struct {
+	Deposits       []*PendingDeposit `json:"deposits"`
+	Truncated      bool              `json:"truncated"`
+	MilestoneIndex milestone.Index   `json:"milestoneIndex"`
+	Duration       int               `json:"duration"`
+}
+
+/////////////////// getOldestUnconfirmedTransaction ////////////////////////////
+
+// GetOldestUnconfirmedTransaction struct
+type GetOldestUnconfirmedTransaction struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetOldestUnconfirmedTransactionReturn struct
+type GetOldestUnconfirmedTransactionReturn struct {
+	TxHash         trinary.Hash    `json:"txHash,omitempty"`
+	AgeSeconds     int64           `json:"ageSeconds"`
+	MilestoneDelta milestone.Index `json:"milestoneDelta"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
 	Duration       int             `json:"duration"`
 }
 
@@ -133,6 +378,39 @@ type GetInclusionStatesReturn struct {
 	Duration int    `json:"duration"`
 }
 
+/////////////////////// getInclusionPath //////////////////////////
+
+// GetInclusionPath struct
+type GetInclusionPath struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// GetInclusionPathReturn struct
+type GetInclusionPathReturn struct {
+	Path           []trinary.Hash  `json:"path"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Duration       int             `json:"duration"`
+}
+
+////////////////////// getWhiteFlagMerkleProof ////////////////////
+
+// GetWhiteFlagMerkleProof struct
+type GetWhiteFlagMerkleProof struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// GetWhiteFlagMerkleProofReturn struct
+type GetWhiteFlagMerkleProofReturn struct {
+	MilestoneIndex          milestone.Index `json:"milestoneIndex"`
+	MilestoneMerkleTreeHash string          `json:"milestoneMerkleTreeHash"`
+	LeafIndex               int             `json:"leafIndex"`
+	LeavesCount             int             `json:"leavesCount"`
+	AuditPath               []string        `json:"auditPath"`
+	Duration                int             `json:"duration"`
+}
+
 ////////////////////// getNeighbors ///////////////////////////////
 
 // GetNeighbors struct
@@ -170,7 +448,12 @@ type GetNodeInfoReturn struct {
 	Time                               int64           `json:"time"`
 	Tips                               uint32          `json:"tips"`
 	TransactionsToRequest              int             `json:"transactionsToRequest"`
+	TransactionsToProcess              int             `json:"transactionsToProcess"`
+	MilestonesToSolidify               milestone.Index `json:"milestonesToSolidify"`
+	TransactionsStored                 int64           `json:"transactionsStored"`
+	IsSnapshotting                     bool            `json:"isSnapshotting"`
 	Features                           []string        `json:"features"`
+	RemotePoW                          bool            `json:"remotePoW"`
 	CoordinatorAddress                 trinary.Hash    `json:"coordinatorAddress"`
 	Duration                           int             `json:"duration"`
 }
@@ -192,6 +475,120 @@ type GetNodeAPIConfigurationReturn struct {
 	Duration            int             `json:"duration"`
 }
 
+////////////////// getProtocolParameters //////////////////////////
+
+// GetProtocolParameters struct
+type GetProtocolParameters struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetProtocolParametersReturn struct
+type GetProtocolParametersReturn struct {
+	NetworkID                   uint32       `json:"networkId"`
+	GossipServiceKey            string       `json:"gossipServiceKey"`
+	CoordinatorAddress          trinary.Hash `json:"coordinatorAddress"`
+	CoordinatorSecurityLevel    int          `json:"coordinatorSecurityLevel"`
+	CoordinatorMerkleTreeDepth  int          `json:"coordinatorMerkleTreeDepth"`
+	MilestoneMerkleTreeHashFunc string       `json:"milestoneMerkleTreeHashFunc"`
+	MinWeightMagnitude          int          `json:"minWeightMagnitude"`
+	TransactionSize             int          `json:"transactionSize"`
+	BelowMaxDepth               int          `json:"belowMaxDepth"`
+	Duration                    int          `json:"duration"`
+}
+
+// ProtocolParameters struct
+type ProtocolParameters struct {
+	NetworkID                   uint32       `json:"networkId"`
+	GossipServiceKey            string       `json:"gossipServiceKey"`
+	CoordinatorAddress          trinary.Hash `json:"coordinatorAddress"`
+	CoordinatorSecurityLevel    int          `json:"coordinatorSecurityLevel"`
+	CoordinatorMerkleTreeDepth  int          `json:"coordinatorMerkleTreeDepth"`
+	MilestoneMerkleTreeHashFunc string       `json:"milestoneMerkleTreeHashFunc"`
+	MinWeightMagnitude          int          `json:"minWeightMagnitude"`
+	TransactionSize             int          `json:"transactionSize"`
+	BelowMaxDepth               int          `json:"belowMaxDepth"`
+}
+
+////////////////// getConfirmationLatency //////////////////////////
+
+// GetConfirmationLatency struct
+type GetConfirmationLatency struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetConfirmationLatencyReturn struct
+type GetConfirmationLatencyReturn struct {
+	Samples  int     `json:"samples"`
+	P50      float64 `json:"p50"`
+	P95      float64 `json:"p95"`
+	P99      float64 `json:"p99"`
+	Duration int     `json:"duration"`
+}
+
+////////////////// getConflictCounts //////////////////////////
+
+// GetConflictCounts struct
+type GetConflictCounts struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetConflictCountsReturn struct
+type GetConflictCountsReturn struct {
+	Milestones []*tangleplugin.MilestoneConflictCount `json:"milestones"`
+	Total      int                                    `json:"total"`
+	Duration   int                                    `json:"duration"`
+}
+
+////////////////// getRecentlyConfirmedTransactions //////////////////////////
+
+// GetRecentlyConfirmedTransactions struct
+type GetRecentlyConfirmedTransactions struct {
+	Command string `mapstructure:"command"`
+	Limit   int    `mapstructure:"limit"`
+}
+
+// GetRecentlyConfirmedTransactionsReturn struct
+type GetRecentlyConfirmedTransactionsReturn struct {
+	Transactions []*tangleplugin.ConfirmedTransaction `json:"transactions"`
+	Duration     int                                  `json:"duration"`
+}
+
+////////////////// getTransactionSizeStats //////////////////////////
+
+// GetTransactionSizeStats struct
+type GetTransactionSizeStats struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetTransactionSizeStatsReturn struct
+type GetTransactionSizeStatsReturn struct {
+	Count    int     `json:"count"`
+	Average  float64 `json:"average"`
+	Min      int     `json:"min"`
+	Max      int     `json:"max"`
+	P95      int     `json:"p95"`
+	Duration int     `json:"duration"`
+}
+
+////////////////// getPlugins //////////////////////////
+
+// GetPlugins struct
+type GetPlugins struct {
+	Command string `mapstructure:"command"`
+}
+
+// PluginStatus struct
+type PluginStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// GetPluginsReturn struct
+type GetPluginsReturn struct {
+	Plugins  []*PluginStatus `json:"plugins"`
+	Duration int             `json:"duration"`
+}
+
 ///////////////// getTipInfo ////////////////////////
 
 // GetTipInfo struct
@@ -216,13 +613,91 @@ type GetTransactionsToApprove struct {
 	Command   string       `mapstructure:"command"`
 	Depth     uint         `mapstructure:"depth"`
 	Reference trinary.Hash `mapstructure:"reference"`
+	// WithProtocol adds the protocol parameters a transaction has to satisfy to the response.
+	WithProtocol bool `mapstructure:"withProtocol"`
+	// Extended adds the metadata of the selected tips to the response.
+	Extended bool `mapstructure:"extended"`
+}
+
+// TipMetadata struct
+type TipMetadata struct {
+	Hash trinary.Hash `json:"hash"`
+	// YTRSIDelta and OTRSIDelta are the distances of the youngest and oldest transaction root snapshot index to the LSMI.
+	YTRSIDelta milestone.Index `json:"ytrsiDelta"`
+	OTRSIDelta milestone.Index `json:"otrsiDelta"`
+	// Score is "nonLazy", "semiLazy" or "lazy".
+	Score string `json:"score"`
 }
 
 // GetTransactionsToApproveReturn struct
 type GetTransactionsToApproveReturn struct {
 	TrunkTransaction  trinary.Hash `json:"trunkTransaction"`
 	BranchTransaction trinary.Hash `json:"branchTransaction"`
-	Duration          int          `json:"duration"`
+	Fallback          bool         `json:"fallback,omitempty"`
+	// Unsafe is set if the tips were selected while the node was not synced.
+	// transactions attached to these tips may be orphaned.
+	Unsafe bool `json:"unsafe,omitempty"`
+	// Protocol is only set if "withProtocol" was requested.
+	Protocol *ProtocolParameters `json:"protocol,omitempty"`
+	// Tips is only set if "extended" was requested, it contains the metadata of the trunk and branch.
+	Tips     []*TipMetadata `json:"tips,omitempty"`
+	Duration int            `json:"duration"`
+}
+
+///////////////// getRecentTipSelections ////////////////////////
+
+// GetRecentTipSelections struct
+type GetRecentTipSelections struct {
+	Command string `mapstructure:"command"`
+}
+
+// RecentTipSelection struct
+type RecentTipSelection struct {
+	Tips      []trinary.Hash `json:"tips"`
+	Pool      string         `json:"pool"`
+	Timestamp int64          `json:"timestamp"`
+}
+
+// GetRecentTipSelectionsReturn struct
+type GetRecentTipSelectionsReturn struct {
+	Selections []*RecentTipSelection `json:"selections"`
+	Duration   int                   `json:"duration"`
+}
+
+///////////////// getTipCount ////////////////////////
+
+// GetTipCount struct
+type GetTipCount struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetTipCountReturn struct
+type GetTipCountReturn struct {
+	NonLazy  uint32 `json:"nonLazy"`
+	SemiLazy uint32 `json:"semiLazy"`
+	Total    uint32 `json:"total"`
+	Duration int    `json:"duration"`
+}
+
+///////////////// getNonLazyTips ////////////////////////
+
+// GetNonLazyTips struct
+type GetNonLazyTips struct {
+	Command string `mapstructure:"command"`
+	Count   int    `mapstructure:"count"`
+}
+
+// GetNonLazyTipsReturn struct
+type GetNonLazyTipsReturn struct {
+	Tips []trinary.Hash `json:"tips"`
+	// Exhausted is set if the pool contained less non-lazy tips than requested.
+	Exhausted bool `json:"exhausted"`
+	// Fallback is set if the pool was empty and the tail transaction of the latest solid milestone was returned instead.
+	Fallback bool `json:"fallback,omitempty"`
+	// Unsafe is set if the tips were selected while the node was not synced.
+	// transactions attached to these tips may be orphaned.
+	Unsafe   bool `json:"unsafe,omitempty"`
+	Duration int  `json:"duration"`
 }
 
 //////////////////////// getTrytes ////////////////////////////////
@@ -253,6 +728,85 @@ type RemoveNeighborsReturn struct {
 	Duration         int  `json:"duration"`
 }
 
+/////////////////// resetNeighborMetrics ////////////////////////////
+
+// ResetNeighborMetrics struct
+type ResetNeighborMetrics struct {
+	Command  string `mapstructure:"command"`
+	Identity string `mapstructure:"identity"`
+}
+
+// ResetNeighborMetricsReturn struct
+type ResetNeighborMetricsReturn struct {
+	Neighbor *peer.Info `json:"neighbor"`
+	Duration int        `json:"duration"`
+}
+
+/////////////////////// pingNeighbors ///////////////////////////////
+
+// PingNeighbors struct
+type PingNeighbors struct {
+	Command  string `mapstructure:"command"`
+	Identity string `mapstructure:"identity,omitempty"`
+}
+
+// NeighborPing struct
+type NeighborPing struct {
+	Identity string `json:"identity"`
+	RTTMs    int64  `json:"rttMs"`
+	Cached   bool   `json:"cached"`
+	Error    string `json:"error,omitempty"`
+}
+
+// PingNeighborsReturn struct
+type PingNeighborsReturn struct {
+	Pings    []*NeighborPing `json:"pings"`
+	Duration int             `json:"duration"`
+}
+
+////////////////////// getNeighborErrors ////////////////////////////
+
+// GetNeighborErrors struct
+type GetNeighborErrors struct {
+	Command  string `mapstructure:"command"`
+	Identity string `mapstructure:"identity,omitempty"`
+}
+
+// NeighborErrors struct
+type NeighborErrors struct {
+	Identity string                  `json:"identity"`
+	Errors   []*peeringpkg.PeerError `json:"errors"`
+}
+
+// GetNeighborErrorsReturn struct
+type GetNeighborErrorsReturn struct {
+	Neighbors []*NeighborErrors `json:"neighbors"`
+	Duration  int               `json:"duration"`
+}
+
+////////////////////// getNodeIdentity ////////////////////////////
+
+// GetNodeIdentity struct
+type GetNodeIdentity struct {
+	Command string `mapstructure:"command"`
+}
+
+// AutopeeringIdentity struct
+type AutopeeringIdentity struct {
+	ID          string `json:"id"`
+	PublicKey   string `json:"publicKey"`
+	BindAddress string `json:"bindAddress"`
+	// EntryNode is the definition which other nodes can use to add this node as an autopeering entry node.
+	EntryNode string `json:"entryNode"`
+}
+
+// GetNodeIdentityReturn struct
+type GetNodeIdentityReturn struct {
+	GossipBindAddress string               `json:"gossipBindAddress"`
+	Autopeering       *AutopeeringIdentity `json:"autopeering,omitempty"`
+	Duration          int                  `json:"duration"`
+}
+
 ////////////////////// storeTransactions //////////////////////////
 
 // StoreTransactions struct
@@ -261,6 +815,95 @@ type StoreTransactions struct {
 	Trytes  []trinary.Trytes `mapstructure:"trytes"`
 }
 
+////////////////////// stream/broadcast ///////////////////////////
+
+// StreamBroadcastRequest is a submission which is pushed by the client over the broadcast stream.
+type StreamBroadcastRequest struct {
+	// ID is chosen by the client to match the result to the submission.
+	ID     string           `json:"id"`
+	Trytes []trinary.Trytes `json:"trytes"`
+}
+
+// StreamBroadcastResult is the result of a submission which is sent to the client over the broadcast stream.
+type StreamBroadcastResult struct {
+	ID       string         `json:"id"`
+	TxHashes []trinary.Hash `json:"txHashes,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	// QueueDepth is the amount of submissions of all connections which were waiting for a free worker after this submission was processed.
+	QueueDepth uint32 `json:"queueDepth"`
+}
+
+/////////////////// streamMilestones ////////////////////////////
+
+// StreamMilestone is sent to the client over the milestone stream for every new solid milestone.
+type StreamMilestone struct {
+	Index         milestone.Index `json:"index"`
+	MilestoneHash trinary.Hash    `json:"milestoneHash"`
+	Timestamp     int64           `json:"timestamp"`
+}
+
+/////////////////// streamAddresses ////////////////////////////
+
+// StreamAddressSubscription is sent by the client over the address stream to change the subscribed addresses.
+type StreamAddressSubscription struct {
+	Subscribe   []trinary.Hash `json:"subscribe"`
+	Unsubscribe []trinary.Hash `json:"unsubscribe"`
+}
+
+// StreamAddressEvent is sent to the client over the address stream for every confirmed transfer of a subscribed address.
+type StreamAddressEvent struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Address        trinary.Hash    `json:"address"`
+	TxHash         trinary.Hash    `json:"txHash"`
+	TailTxHash     trinary.Hash    `json:"tailTxHash"`
+	// Type is "created" if funds were transferred to the address and "spent" if funds were spent from the address.
+	Type   string `json:"type"`
+	Amount uint64 `json:"amount"`
+}
+
+//////////////////// previewTransfer ////////////////////////////
+
+// PreviewTransfer struct
+type PreviewTransfer struct {
+	Command string            `mapstructure:"command"`
+	Inputs  []trinary.Hash    `mapstructure:"inputs"`
+	Outputs []*TransferOutput `mapstructure:"outputs"`
+}
+
+// TransferOutput struct
+type TransferOutput struct {
+	Address trinary.Hash `mapstructure:"address"`
+	Value   uint64       `mapstructure:"value"`
+}
+
+// TransferPreviewInput struct
+type TransferPreviewInput struct {
+	Address trinary.Hash `json:"address"`
+	Balance uint64       `json:"balance"`
+	Spent   bool         `json:"spent"`
+}
+
+// TransferPreviewError struct
+type TransferPreviewError struct {
+	Code    string       `json:"code"`
+	Address trinary.Hash `json:"address,omitempty"`
+	Message string       `json:"message"`
+}
+
+// PreviewTransferReturn struct
+type PreviewTransferReturn struct {
+	Inputs      []*TransferPreviewInput `json:"inputs"`
+	TotalInput  uint64                  `json:"totalInput"`
+	TotalOutput uint64                  `json:"totalOutput"`
+	Change      uint64                  `json:"change"`
+	Shortfall   uint64                  `json:"shortfall"`
+	Valid       bool                    `json:"valid"`
+	Errors      []*TransferPreviewError `json:"errors"`
+	LedgerIndex milestone.Index         `json:"ledgerIndex"`
+	Duration    int                     `json:"duration"`
+}
+
 /////////////////// wereAddressesSpentFrom ////////////////////////
 
 // WereAddressesSpentFrom struct
@@ -336,6 +979,10 @@ type GetLedgerDiffExtReturn struct {
 type GetLedgerState struct {
 	Command     string          `mapstructure:"command"`
 	TargetIndex milestone.Index `mapstructure:"targetIndex,omitempty"`
+	// MinBalance only returns addresses with at least the given balance.
+	MinBalance int64 `mapstructure:"minBalance,omitempty"`
+	// MaxBalance only returns addresses with at most the given balance (0 = no limit).
+	MaxBalance int64 `mapstructure:"maxBalance,omitempty"`
 }
 
 // GetLedgerStateReturn struct
@@ -345,12 +992,360 @@ type GetLedgerStateReturn struct {
 	Duration       int                     `json:"duration"`
 }
 
+/////////////////// getLedgerDiffSizes ////////////////////////
+
+// GetLedgerDiffSizes struct
+type GetLedgerDiffSizes struct {
+	Command    string          `mapstructure:"command"`
+	StartIndex milestone.Index `mapstructure:"startIndex"`
+	EndIndex   milestone.Index `mapstructure:"endIndex,omitempty"`
+}
+
+// LedgerDiffSize struct
+type LedgerDiffSize struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Deposits       int             `json:"deposits"`
+	Withdrawals    int             `json:"withdrawals"`
+}
+
+// GetLedgerDiffSizesReturn struct
+type GetLedgerDiffSizesReturn struct {
+	Sizes    []*LedgerDiffSize `json:"sizes"`
+	Duration int               `json:"duration"`
+}
+
+/////////////////// getAddressHistory ////////////////////////
+
+// GetAddressHistory struct
+type GetAddressHistory struct {
+	Command    string          `mapstructure:"command"`
+	Address    trinary.Hash    `mapstructure:"address"`
+	StartIndex milestone.Index `mapstructure:"startIndex"`
+	EndIndex   milestone.Index `mapstructure:"endIndex,omitempty"`
+}
+
+// AddressHistoryEntry struct
+type AddressHistoryEntry struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Change         int64           `json:"change"`
+	// TxHashes are the hashes of the confirmed value transactions of the address.
+	TxHashes []trinary.Hash `json:"txHashes"`
+}
+
+// GetAddressHistoryReturn struct
+type GetAddressHistoryReturn struct {
+	Address trinary.Hash           `json:"address"`
+	History []*AddressHistoryEntry `json:"history"`
+	// NextIndex is the start index of the next page, it is zero if the range reached the latest solid milestone.
+	NextIndex milestone.Index `json:"nextIndex,omitempty"`
+	Duration  int             `json:"duration"`
+}
+
+/////////////////// getConfirmedTransactionCounts ////////////////////////
+
+// GetConfirmedTransactionCounts struct
+type GetConfirmedTransactionCounts struct {
+	Command    string          `mapstructure:"command"`
+	StartIndex milestone.Index `mapstructure:"startIndex"`
+	EndIndex   milestone.Index `mapstructure:"endIndex,omitempty"`
+}
+
+// ConfirmedTransactionCount struct
+type ConfirmedTransactionCount struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	Transactions   int             `json:"transactions"`
+	Bundles        int             `json:"bundles"`
+	Conflicting    int             `json:"conflicting"`
+}
+
+// GetConfirmedTransactionCountsReturn struct
+type GetConfirmedTransactionCountsReturn struct {
+	Counts   []*ConfirmedTransactionCount `json:"counts"`
+	Duration int                          `json:"duration"`
+}
+
+/////////////////// getDatabaseStats ////////////////////////
+
+// DatabaseBucketStats struct
+type DatabaseBucketStats struct {
+	Name           string `json:"name"`
+	KeyCount       int    `json:"keyCount"`
+	InUseBytes     int    `json:"inUseBytes"`
+	AllocatedBytes int    `json:"allocatedBytes"`
+}
+
+// DatabaseStats struct
+type DatabaseStats struct {
+	Name             string                 `json:"name"`
+	Size             int64                  `json:"size"`
+	FreePageCount    int                    `json:"freePageCount"`
+	PendingPageCount int                    `json:"pendingPageCount"`
+	FreeAllocBytes   int                    `json:"freeAllocBytes"`
+	OpenReadTxCount  int                    `json:"openReadTxCount"`
+	Buckets          []*DatabaseBucketStats `json:"buckets"`
+}
+
+// GetDatabaseStatsReturn struct
+type GetDatabaseStatsReturn struct {
+	Databases []*DatabaseStats `json:"databases"`
+	Duration  int              `json:"duration"`
+}
+
+/////////////////// getDatabaseVersion ////////////////////////
+
+// GetDatabaseVersion struct
+type GetDatabaseVersion struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetDatabaseVersionReturn struct
+type GetDatabaseVersionReturn struct {
+	Version         int `json:"version"`
+	ExpectedVersion int `json:"expectedVersion"`
+}
+
+/////////////////// getMilestoneHashes ////////////////////////
+
+// GetMilestoneHashes struct
+type GetMilestoneHashes struct {
+	Command    string          `mapstructure:"command"`
+	StartIndex milestone.Index `mapstructure:"startIndex"`
+	EndIndex   milestone.Index `mapstructure:"endIndex"`
+	// Latest returns the hashes of the latest N solid milestones instead of a range.
+	Latest int `mapstructure:"latest"`
+}
+
+// MilestoneHash struct
+type MilestoneHash struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	MilestoneHash  trinary.Hash    `json:"milestoneHash"`
+}
+
+// GetMilestoneHashesReturn struct
+type GetMilestoneHashesReturn struct {
+	Milestones []*MilestoneHash `json:"milestones"`
+	Duration   int              `json:"duration"`
+}
+
+/////////////////// healthz ////////////////////////
+
+// HealthzReturn struct
+type HealthzReturn struct {
+	FailedChecks []string `json:"failedChecks"`
+}
+
+/////////////////// milestones/:index ////////////////////////
+
+// MilestoneByIndexReturn struct
+type MilestoneByIndexReturn struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	MilestoneHash  trinary.Hash    `json:"milestoneHash"`
+	Timestamp      int64           `json:"timestamp"`
+}
+
+/////////////////// milestones/:index/funded-addresses ///////////////////////////
+
+// FundedAddress struct
+type FundedAddress struct {
+	Address trinary.Hash `json:"address"`
+	Amount  uint64       `json:"amount"`
+}
+
+// MilestoneFundedAddressesReturn struct
+type MilestoneFundedAddressesReturn struct {
+	MilestoneIndex milestone.Index  `json:"milestoneIndex"`
+	Addresses      []*FundedAddress `json:"addresses"`
+	// AddressesCount is the amount of all funded addresses of the milestone, not only of the current page.
+	AddressesCount int `json:"addressesCount"`
+	// TotalAmount is the sum of the funds received by all addresses of the milestone.
+	TotalAmount uint64 `json:"totalAmount"`
+	// NextCursor is set if there are more addresses, it has to be passed as "cursor" to get the next page.
+	NextCursor trinary.Hash `json:"nextCursor,omitempty"`
+}
+
+/////////////////// milestoneOutputs ////////////////////////////////
+
+// MilestoneOutput struct
+type MilestoneOutput struct {
+	TxHash     trinary.Hash `json:"txHash"`
+	TailTxHash trinary.Hash `json:"tailTxHash"`
+	BundleHash trinary.Hash `json:"bundleHash"`
+	Address    trinary.Hash `json:"address"`
+	Value      uint64       `json:"value"`
+}
+
+// MilestoneOutputsReturn struct
+type MilestoneOutputsReturn struct {
+	MilestoneIndex milestone.Index    `json:"milestoneIndex"`
+	Outputs        []*MilestoneOutput `json:"outputs"`
+	// OutputsCount is the amount of all outputs of the milestone matching the filters, not only of the current page.
+	OutputsCount int `json:"outputsCount"`
+	// NextCursor is set if there are more outputs, it has to be passed as "cursor" to get the next page.
+	NextCursor trinary.Hash `json:"nextCursor,omitempty"`
+}
+
+/////////////////// milestones/:index/cone-status ///////////////////////////
+
+// MilestoneConeStatusReturn struct
+type MilestoneConeStatusReturn struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// Pruned is set if the milestone is below the pruning index, the transactions of its cone are not counted then.
+	Pruned   bool `json:"pruned"`
+	Complete bool `json:"complete"`
+	Present  int  `json:"present"`
+	Missing  int  `json:"missing"`
+}
+
+/////////////////// getMilestonesByTimestamp ///////////////////////////
+
+// GetMilestonesByTimestamp struct
+type GetMilestonesByTimestamp struct {
+	Command       string `mapstructure:"command"`
+	FromTimestamp int64  `mapstructure:"fromTimestamp"`
+	ToTimestamp   int64  `mapstructure:"toTimestamp"`
+}
+
+// GetMilestonesByTimestampReturn struct
+type GetMilestonesByTimestampReturn struct {
+	Milestones []*MilestoneByIndexReturn `json:"milestones"`
+	// Truncated is set if more milestones are within the range than allowed to be returned.
+	Truncated bool `json:"truncated"`
+	Duration  int  `json:"duration"`
+}
+
+/////////////////// transactions/:hash/full ///////////////////////////
+
+// TransactionMetadataReturn struct
+type TransactionMetadataReturn struct {
+	Hash                      trinary.Hash    `json:"hash"`
+	BundleHash                trinary.Hash    `json:"bundleHash"`
+	TrunkTransaction          trinary.Hash    `json:"trunkTransaction"`
+	BranchTransaction         trinary.Hash    `json:"branchTransaction"`
+	IsTail                    bool            `json:"isTail"`
+	IsHead                    bool            `json:"isHead"`
+	Solid                     bool            `json:"solid"`
+	SolidificationTimestamp   int32           `json:"solidificationTimestamp"`
+	Confirmed                 bool            `json:"confirmed"`
+	ConfirmationIndex         milestone.Index `json:"confirmationIndex"`
+	MilestoneTimestamp        *int64          `json:"milestoneTimestamp,omitempty"`
+	WhiteFlagIndex            *uint32         `json:"whiteFlagIndex,omitempty"`
+	Conflicting               bool            `json:"conflicting"`
+	YoungestRootSnapshotIndex milestone.Index `json:"youngestRootSnapshotIndex"`
+	OldestRootSnapshotIndex   milestone.Index `json:"oldestRootSnapshotIndex"`
+}
+
+// TransactionFullReturn struct
+type TransactionFullReturn struct {
+	Trytes   trinary.Trytes             `json:"trytes"`
+	Metadata *TransactionMetadataReturn `json:"metadata"`
+}
+
+/////////////////// transactionsByHashes ///////////////////////////
+
+// TransactionByHash struct
+type TransactionByHash struct {
+	Hash trinary.Hash `json:"hash"`
+	// Transaction is nil if the transaction could not be loaded.
+	Transaction *TransactionFullReturn `json:"transaction,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Code        string                 `json:"code,omitempty"`
+}
+
+// TransactionsByHashesReturn struct
+type TransactionsByHashesReturn struct {
+	Transactions []*TransactionByHash `json:"transactions"`
+}
+
+/////////////////// transactionHashFromBytes ///////////////////////////
+
+// TransactionHashFromBytesReturn struct
+type TransactionHashFromBytesReturn struct {
+	Hash trinary.Hash `json:"hash"`
+}
+
+/////////////////// peersExport / peersImport ///////////////////////////
+
+// PeersExport struct
+type PeersExport struct {
+	Peers []config.PeerConfig `json:"peers"`
+}
+
+// PeerImportResult struct
+type PeerImportResult struct {
+	Identity string `json:"identity"`
+	Alias    string `json:"alias"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// PeersImportReturn struct
+type PeersImportReturn struct {
+	Mode    string              `json:"mode"`
+	Results []*PeerImportResult `json:"results"`
+}
+
+/////////////////// submitMilestone ///////////////////////////
+
+// SubmitMilestone struct
+type SubmitMilestone struct {
+	Command string           `mapstructure:"command"`
+	Trytes  []trinary.Trytes `mapstructure:"trytes"`
+}
+
+// SubmitMilestoneReturn struct
+type SubmitMilestoneReturn struct {
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	MilestoneHash  trinary.Hash    `json:"milestoneHash"`
+	Duration       int             `json:"duration"`
+}
+
+/////////////////// clearTransactionFilter ////////////////////
+
+// ClearTransactionFilterReturn struct
+type ClearTransactionFilterReturn struct {
+	Cleared  int `json:"cleared"`
+	Duration int `json:"duration"`
+}
+
+/////////////////// reprocessTransaction ////////////////////
+
+// ReprocessTransaction struct
+type ReprocessTransaction struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// ReprocessTransactionReturn struct
+type ReprocessTransactionReturn struct {
+	// Outcome is either "duplicate" or "dropped".
+	// only stored transactions can be reprocessed, so the ones which pass all checks would not be stored again.
+	Outcome  string `json:"outcome"`
+	Reason   string `json:"reason,omitempty"`
+	Duration int    `json:"duration"`
+}
+
+/////////////////// getTransactionArrivalSource ////////////////////
+
+// GetTransactionArrivalSource struct
+type GetTransactionArrivalSource struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// GetTransactionArrivalSourceReturn struct
+type GetTransactionArrivalSourceReturn struct {
+	TxHash   trinary.Hash `json:"txHash"`
+	Source   string       `json:"source"`
+	Duration int          `json:"duration"`
+}
+
 /////////////////// createSnapshotFile ////////////////////////
 
 // CreateSnapshotFile struct
 type CreateSnapshotFile struct {
 	Command     string          `mapstructure:"command"`
 	TargetIndex milestone.Index `mapstructure:"targetIndex"`
+	Stream      bool            `mapstructure:"stream"`
 }
 
 // CreateSnapshotFileReturn struct
@@ -358,6 +1353,59 @@ type CreateSnapshotFileReturn struct {
 	Duration int `json:"duration"`
 }
 
+// CreateSnapshotFileProgress struct
+type CreateSnapshotFileProgress struct {
+	Stage          string          `json:"stage,omitempty"`
+	MilestoneIndex milestone.Index `json:"milestoneIndex,omitempty"`
+	Done           bool            `json:"done,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+/////////////////// getSolidEntryPointsCount ////////////////////////
+
+// GetSolidEntryPointsCount struct
+type GetSolidEntryPointsCount struct {
+	Command string `mapstructure:"command"`
+}
+
+// GetSolidEntryPointsCountReturn struct
+type GetSolidEntryPointsCountReturn struct {
+	Count           int             `json:"count"`
+	EntryPointIndex milestone.Index `json:"entryPointIndex"`
+	Duration        int             `json:"duration"`
+}
+
+/////////////////// getCheckpoint ////////////////////////
+
+// GetCheckpoint struct
+type GetCheckpoint struct {
+	Command        string          `mapstructure:"command"`
+	MilestoneIndex milestone.Index `mapstructure:"milestoneIndex,omitempty"`
+}
+
+// GetCheckpointReturn struct
+type GetCheckpointReturn struct {
+	MilestoneIndex          milestone.Index `json:"milestoneIndex"`
+	MilestoneHash           trinary.Hash    `json:"milestoneHash"`
+	MilestoneTimestamp      int64           `json:"milestoneTimestamp"`
+	MilestoneMerkleTreeHash string          `json:"milestoneMerkleTreeHash"`
+	CoordinatorAddress      trinary.Hash    `json:"coordinatorAddress"`
+	LedgerStateHash         string          `json:"ledgerStateHash"`
+	LedgerAddressesCount    int             `json:"ledgerAddressesCount"`
+	Duration                int             `json:"duration"`
+}
+
+/////////////////// ledgerStateHash ////////////////////////
+
+// LedgerStateHashReturn struct
+type LedgerStateHashReturn struct {
+	MilestoneIndex       milestone.Index `json:"milestoneIndex"`
+	LedgerStateHash      string          `json:"ledgerStateHash"`
+	LedgerAddressesCount int             `json:"ledgerAddressesCount"`
+	// Cached is set if the hash was already computed for this milestone by an earlier request.
+	Cached bool `json:"cached"`
+}
+
 /////////////////// pruneDatabase ////////////////////////
 
 // PruneDatabase struct
@@ -449,6 +1497,21 @@ type TriggerSolidifier struct {
 	Command string `mapstructure:"command"`
 }
 
+///////////////// solidifySubtangle /////////////////////////
+
+// SolidifySubtangle struct
+type SolidifySubtangle struct {
+	Command string       `mapstructure:"command"`
+	TxHash  trinary.Hash `mapstructure:"txHash"`
+}
+
+// SolidifySubtangleReturn struct
+type SolidifySubtangleReturn struct {
+	Solid            bool           `json:"solid"`
+	MissingRequested []trinary.Hash `json:"missingRequested"`
+	Duration         int            `json:"duration"`
+}
+
 /////////////////// getFundsOnSpentAddresses //////////////////////////////
 
 // GetFundsOnSpentAddressesReturn struct
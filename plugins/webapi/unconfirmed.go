@@ -0,0 +1,92 @@
+package webapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/config"
+	"github.com/gohornet/hornet/pkg/model/milestone"
+	"github.com/gohornet/hornet/pkg/model/tangle"
+)
+
+func init() {
+	addEndpoint("getOldestUnconfirmedTransaction", getOldestUnconfirmedTransaction, implementedAPIcalls)
+}
+
+// getOldestUnconfirmedTransaction returns the age of the oldest solid but unconfirmed transaction.
+// the unconfirmed transactions are indexed by the latest milestone index at the time they arrived,
+// so only the buckets of the last "tipsel.belowMaxDepth" milestones need to be checked.
+// older transactions can't be confirmed by the tipselection anymore and are ignored.
+// a rising age signals that the coordinator or the tipselection is unhealthy.
+func getOldestUnconfirmedTransaction(_ interface{}, c *gin.Context, abortSignal <-chan struct{}) {
+	e := ErrorReturn{}
+
+	if !tangle.WaitForNodeSynced(waitForNodeSyncedTimeout) {
+		e.Error = ErrNodeNotSync.Error()
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	lsmi := tangle.GetSolidMilestoneIndex()
+	lmi := tangle.GetLatestMilestoneIndex()
+
+	startIndex := milestone.Index(1)
+	if belowMaxDepth := milestone.Index(config.NodeConfig.GetInt(config.CfgTipSelBelowMaxDepth)); lsmi > belowMaxDepth {
+		startIndex = lsmi - belowMaxDepth
+	}
+	if snapshotInfo := tangle.GetSnapshotInfo(); snapshotInfo != nil && startIndex <= snapshotInfo.PruningIndex {
+		startIndex = snapshotInfo.PruningIndex + 1
+	}
+
+	result := GetOldestUnconfirmedTransactionReturn{MilestoneIndex: lsmi}
+
+	for msIndex := startIndex; msIndex <= lmi; msIndex++ {
+		select {
+		case <-abortSignal:
+			e.Error = tangle.ErrOperationAborted.Error()
+			c.JSON(http.StatusServiceUnavailable, e)
+			return
+		default:
+		}
+
+		var oldestTxHash trinary.Hash
+		var oldestSolidificationTimestamp int32
+
+		for _, txHash := range tangle.GetUnconfirmedTxHashes(msIndex, true) {
+			cachedTxMeta := tangle.GetCachedTxMetadataOrNil(txHash) // meta +1
+			if cachedTxMeta == nil {
+				continue
+			}
+
+			metadata := cachedTxMeta.GetMetadata()
+			if metadata.IsSolid() && !metadata.IsConfirmed() {
+				if oldestTxHash == "" || metadata.GetSolidificationTimestamp() < oldestSolidificationTimestamp {
+					oldestTxHash = txHash.Trytes()
+					oldestSolidificationTimestamp = metadata.GetSolidificationTimestamp()
+				}
+			}
+			cachedTxMeta.Release(true) // meta -1
+		}
+
+		if oldestTxHash == "" {
+			continue
+		}
+
+		// all transactions in the following buckets arrived later
+		result.TxHash = oldestTxHash
+		result.AgeSeconds = time.Now().Unix() - int64(oldestSolidificationTimestamp)
+		if result.AgeSeconds < 0 {
+			result.AgeSeconds = 0
+		}
+		if lsmi > msIndex {
+			result.MilestoneDelta = lsmi - msIndex
+		}
+		break
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,117 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/math"
+	"github.com/iotaledger/iota.go/transaction"
+	"github.com/iotaledger/iota.go/trinary"
+
+	"github.com/gohornet/hornet/pkg/model/hornet"
+	"github.com/gohornet/hornet/plugins/gossip"
+)
+
+func init() {
+	addEndpoint("validateTransactions", validateTransactions, implementedAPIcalls)
+}
+
+// validateTransactions checks the structure, the values and the signatures of the given bundle
+// and returns a list of problems. The trunk, branch and nonce of the transactions are ignored,
+// so the bundle can be checked before tipselection and PoW are done.
+// The transactions are neither stored nor broadcasted.
+func validateTransactions(i interface{}, c *gin.Context, _ <-chan struct{}) {
+	e := ErrorReturn{}
+	query := &ValidateTransactions{}
+
+	if err := mapstructure.Decode(i, query); err != nil {
+		e.Error = fmt.Sprintf("%v: %v", ErrInternalError, err)
+		c.JSON(http.StatusInternalServerError, e)
+		return
+	}
+
+	if len(query.Trytes) == 0 {
+		e.Error = "No trytes provided"
+		c.JSON(http.StatusBadRequest, e)
+		return
+	}
+
+	problems := []string{}
+
+	txs := make(transaction.Transactions, 0, len(query.Trytes))
+	for index, trytes := range query.Trytes {
+		if !guards.IsTransactionTrytes(trytes) {
+			problems = append(problems, fmt.Sprintf("trytes %d: %v", index, consts.ErrInvalidTransactionTrytes))
+			continue
+		}
+
+		txTrits := trinary.MustTrytesToTrits(trytes)
+		tx, err := transaction.ParseTransaction(txTrits, true)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("trytes %d: %v", index, err))
+			continue
+		}
+
+		if tx.Value != 0 {
+			// last trit must be zero because of KERL
+			if txTrits[consts.AddressTrinaryOffset+consts.AddressTrinarySize-1] != 0 {
+				problems = append(problems, fmt.Sprintf("trytes %d: %v", index, consts.ErrInvalidAddress))
+			}
+
+			if math.AbsInt64(tx.Value) > consts.TotalSupply {
+				problems = append(problems, fmt.Sprintf("trytes %d: %v", index, consts.ErrInsufficientBalance))
+			}
+		}
+
+		if timeValid, _ := gossip.Processor().ValidateTimestamp(hornet.NewTransactionFromTx(tx, nil)); !timeValid {
+			problems = append(problems, fmt.Sprintf("trytes %d: invalid timestamp: %d", index, tx.Timestamp))
+		}
+
+		txs = append(txs, *tx)
+	}
+
+	if len(problems) > 0 {
+		c.JSON(http.StatusOK, ValidateTransactionsReturn{Valid: false, Problems: problems})
+		return
+	}
+
+	// Sort transactions (lowest to highest index)
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].CurrentIndex < txs[j].CurrentIndex
+	})
+
+	if uint64(len(txs)) != txs[0].LastIndex+1 {
+		problems = append(problems, fmt.Sprintf("invalid bundle length. Received txs: %d, Bundle requires: %d", len(txs), txs[0].LastIndex+1))
+	}
+
+	var sum int64
+	for i := range txs {
+		if txs[i].CurrentIndex != uint64(i) || txs[i].LastIndex != txs[0].LastIndex {
+			problems = append(problems, fmt.Sprintf("invalid transaction index. Got: %d, expected: %d", txs[i].CurrentIndex, i))
+		}
+		if txs[i].Bundle != txs[0].Bundle {
+			problems = append(problems, fmt.Sprintf("transaction %d: bundle hash mismatch", txs[i].CurrentIndex))
+		}
+		sum += txs[i].Value
+	}
+
+	if sum != 0 {
+		problems = append(problems, fmt.Sprintf("invalid bundle value. Sum of values: %d", sum))
+	}
+
+	// the signatures and the bundle hash can only be checked if the structure of the bundle is valid
+	if len(problems) == 0 {
+		if err := bundle.ValidBundle(txs); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	c.JSON(http.StatusOK, ValidateTransactionsReturn{Valid: len(problems) == 0, Problems: problems})
+}